@@ -0,0 +1,55 @@
+package goset
+
+import (
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// bloomFilter answers whether a member may have been added to it, with no false negatives.
+type bloomFilter struct {
+	bits   []uint64
+	hashes int
+}
+
+// newBloomFilter returns a bloomFilter sized to hold count members with the given false positive rate.
+func newBloomFilter(count int, falsePositiveRate float64) *bloomFilter {
+	count = max(count, 1)
+	size := int(math.Ceil(-float64(count) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashes := max(int(math.Round(float64(size)/float64(count)*math.Ln2)), 1)
+	return &bloomFilter{
+		bits:   make([]uint64, (size+63)/64),
+		hashes: hashes,
+	}
+}
+
+// add records member in the filter.
+func (filter *bloomFilter) add(member any) {
+	first, second := bloomHashes(member)
+	size := uint64(len(filter.bits) * 64)
+	for i := 0; i < filter.hashes; i++ {
+		bit := (first + uint64(i)*second) % size
+		filter.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// mayContain returns false if member was certainly never added to the filter.
+func (filter *bloomFilter) mayContain(member any) bool {
+	first, second := bloomHashes(member)
+	size := uint64(len(filter.bits) * 64)
+	for i := 0; i < filter.hashes; i++ {
+		bit := (first + uint64(i)*second) % size
+		if filter.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomHashes returns the two hashes of member combined by double hashing to choose its bits.
+func bloomHashes(member any) (uint64, uint64) {
+	h := fnv.New64a()
+	hashValue(h, reflect.ValueOf(member))
+	first := h.Sum64()
+	return first, mix64(first) | 1
+}
@@ -0,0 +1,309 @@
+package goset
+
+import (
+	"container/list"
+	"math/rand"
+	"sync"
+)
+
+// An EvictionPolicy chooses which member a BoundedSet evicts when it is full.
+// A BoundedSet serializes calls to its EvictionPolicy, so implementations need not be safe for concurrent use.
+type EvictionPolicy[T comparable] interface {
+	// Added is called when member is added to the BoundedSet
+	Added(member T)
+	// Accessed is called when member is found by Contains, or added again
+	Accessed(member T)
+	// Removed is called when member is removed from the BoundedSet, including by eviction
+	Removed(member T)
+	// Victim returns the member to evict. It is only called when the BoundedSet is not empty.
+	Victim() T
+}
+
+// BoundedStats reports the hits, misses and evictions of a BoundedSet
+type BoundedStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// BoundedSet is a set holding at most a fixed number of members, evicting members chosen by its
+// EvictionPolicy to make room for new ones. It is safe for concurrent use.
+type BoundedSet[T comparable] struct {
+	mu       sync.Mutex
+	set      Set[T]
+	capacity int
+	policy   EvictionPolicy[T]
+	onEvict  func(member T)
+	stats    BoundedStats
+}
+
+// NewBounded returns a new, empty BoundedSet holding at most capacity members, evicting according to
+// policy, or NewLRU if policy is nil. It panics if capacity is less than 1.
+func NewBounded[T comparable](capacity int, policy EvictionPolicy[T]) *BoundedSet[T] {
+	if capacity < 1 {
+		panic("goset: BoundedSet capacity must be at least 1")
+	}
+	if policy == nil {
+		policy = NewLRU[T]()
+	}
+	return &BoundedSet[T]{
+		set:      New[T](),
+		capacity: capacity,
+		policy:   policy,
+	}
+}
+
+// OnEvict registers callback to be called with each member evicted from theSet. It is called after
+// the Add causing the eviction has completed, so may safely use theSet.
+func (theSet *BoundedSet[T]) OnEvict(callback func(member T)) *BoundedSet[T] {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	theSet.onEvict = callback
+	return theSet
+}
+
+// Add adds members to theSet, evicting existing members as necessary to stay within capacity
+func (theSet *BoundedSet[T]) Add(members ...T) *BoundedSet[T] {
+	theSet.mu.Lock()
+	evicted := []T{}
+	for _, member := range members {
+		if theSet.set.Contains(member) {
+			theSet.policy.Accessed(member)
+			continue
+		}
+		if theSet.set.Count() >= theSet.capacity {
+			victim := theSet.policy.Victim()
+			theSet.set.Remove(victim)
+			theSet.policy.Removed(victim)
+			theSet.stats.Evictions++
+			evicted = append(evicted, victim)
+		}
+		theSet.set.Add(member)
+		theSet.policy.Added(member)
+	}
+	onEvict := theSet.onEvict
+	theSet.mu.Unlock()
+
+	if onEvict != nil {
+		for _, victim := range evicted {
+			onEvict(victim)
+		}
+	}
+	return theSet
+}
+
+// Remove removes members from theSet, ignoring any that are not present
+func (theSet *BoundedSet[T]) Remove(members ...T) *BoundedSet[T] {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	for _, member := range members {
+		if theSet.set.Contains(member) {
+			theSet.set.Remove(member)
+			theSet.policy.Removed(member)
+		}
+	}
+	return theSet
+}
+
+// Contains returns a boolean indicating whether theSet contains all the given values. Each value
+// found counts as a hit and marks it as accessed; each value not found counts as a miss.
+func (theSet *BoundedSet[T]) Contains(values ...T) bool {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	found := true
+	for _, value := range values {
+		if theSet.set.Contains(value) {
+			theSet.stats.Hits++
+			theSet.policy.Accessed(value)
+		} else {
+			theSet.stats.Misses++
+			found = false
+		}
+	}
+	return found
+}
+
+// Count returns the set cardinality of theSet
+func (theSet *BoundedSet[T]) Count() int {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	return theSet.set.Count()
+}
+
+// Capacity returns the maximum number of members theSet can hold
+func (theSet *BoundedSet[T]) Capacity() int {
+	return theSet.capacity
+}
+
+// AsList returns a slice of values in theSet
+func (theSet *BoundedSet[T]) AsList() []T {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	return theSet.set.AsList()
+}
+
+// Snapshot returns a copy of the current members of theSet
+func (theSet *BoundedSet[T]) Snapshot() Set[T] {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	return theSet.set.Clone()
+}
+
+// Stats returns the hits, misses and evictions of theSet so far
+func (theSet *BoundedSet[T]) Stats() BoundedStats {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	return theSet.stats
+}
+
+// orderedPolicy evicts the member at the front of a list, moving accessed members to the back if moveOnAccess.
+type orderedPolicy[T comparable] struct {
+	order        *list.List
+	elements     map[T]*list.Element
+	moveOnAccess bool
+}
+
+// NewLRU returns an EvictionPolicy evicting the least recently added or accessed member
+func NewLRU[T comparable]() EvictionPolicy[T] {
+	return &orderedPolicy[T]{order: list.New(), elements: map[T]*list.Element{}, moveOnAccess: true}
+}
+
+// NewFIFO returns an EvictionPolicy evicting the least recently added member, regardless of access
+func NewFIFO[T comparable]() EvictionPolicy[T] {
+	return &orderedPolicy[T]{order: list.New(), elements: map[T]*list.Element{}}
+}
+
+func (policy *orderedPolicy[T]) Added(member T) {
+	policy.elements[member] = policy.order.PushBack(member)
+}
+
+func (policy *orderedPolicy[T]) Accessed(member T) {
+	if element, ok := policy.elements[member]; ok && policy.moveOnAccess {
+		policy.order.MoveToBack(element)
+	}
+}
+
+func (policy *orderedPolicy[T]) Removed(member T) {
+	if element, ok := policy.elements[member]; ok {
+		policy.order.Remove(element)
+		delete(policy.elements, member)
+	}
+}
+
+func (policy *orderedPolicy[T]) Victim() T {
+	return policy.order.Front().Value.(T)
+}
+
+// lfuPolicy evicts the least frequently accessed member, keeping a list of members for each access
+// count so that every operation is constant time. Ties are broken by evicting the least recent.
+type lfuPolicy[T comparable] struct {
+	entries     map[T]*list.Element
+	frequencies map[int]*list.List
+	least       int
+}
+
+type lfuEntry[T comparable] struct {
+	member    T
+	frequency int
+}
+
+// NewLFU returns an EvictionPolicy evicting the least frequently added or accessed member
+func NewLFU[T comparable]() EvictionPolicy[T] {
+	return &lfuPolicy[T]{entries: map[T]*list.Element{}, frequencies: map[int]*list.List{}}
+}
+
+func (policy *lfuPolicy[T]) Added(member T) {
+	policy.insert(&lfuEntry[T]{member: member, frequency: 1})
+	policy.least = 1
+}
+
+func (policy *lfuPolicy[T]) Accessed(member T) {
+	element, ok := policy.entries[member]
+	if !ok {
+		return
+	}
+	entry := element.Value.(*lfuEntry[T])
+	policy.unlink(element)
+	if entry.frequency == policy.least && policy.frequencies[entry.frequency] == nil {
+		policy.least++
+	}
+	entry.frequency++
+	policy.insert(entry)
+}
+
+func (policy *lfuPolicy[T]) Removed(member T) {
+	if element, ok := policy.entries[member]; ok {
+		policy.unlink(element)
+		delete(policy.entries, member)
+	}
+}
+
+func (policy *lfuPolicy[T]) Victim() T {
+	if policy.frequencies[policy.least] == nil {
+		// The least frequent member was removed without eviction, so search for the new least.
+		policy.least = 0
+		for frequency := range policy.frequencies {
+			if policy.least == 0 || frequency < policy.least {
+				policy.least = frequency
+			}
+		}
+	}
+	return policy.frequencies[policy.least].Front().Value.(*lfuEntry[T]).member
+}
+
+func (policy *lfuPolicy[T]) insert(entry *lfuEntry[T]) {
+	bucket, ok := policy.frequencies[entry.frequency]
+	if !ok {
+		bucket = list.New()
+		policy.frequencies[entry.frequency] = bucket
+	}
+	policy.entries[entry.member] = bucket.PushBack(entry)
+}
+
+func (policy *lfuPolicy[T]) unlink(element *list.Element) {
+	frequency := element.Value.(*lfuEntry[T]).frequency
+	bucket := policy.frequencies[frequency]
+	bucket.Remove(element)
+	if bucket.Len() == 0 {
+		delete(policy.frequencies, frequency)
+	}
+}
+
+// randomPolicy evicts a member chosen uniformly at random.
+type randomPolicy[T comparable] struct {
+	members []T
+	indexes map[T]int
+	random  *rand.Rand
+}
+
+// NewRandom returns an EvictionPolicy evicting a member chosen at random using random, or a
+// randomly seeded source if random is nil
+func NewRandom[T comparable](random *rand.Rand) EvictionPolicy[T] {
+	if random == nil {
+		random = rand.New(rand.NewSource(rand.Int63()))
+	}
+	return &randomPolicy[T]{indexes: map[T]int{}, random: random}
+}
+
+func (policy *randomPolicy[T]) Added(member T) {
+	policy.indexes[member] = len(policy.members)
+	policy.members = append(policy.members, member)
+}
+
+func (policy *randomPolicy[T]) Accessed(member T) {}
+
+func (policy *randomPolicy[T]) Removed(member T) {
+	idx, ok := policy.indexes[member]
+	if !ok {
+		return
+	}
+	last := policy.members[len(policy.members)-1]
+	policy.members[idx] = last
+	policy.indexes[last] = idx
+	policy.members = policy.members[:len(policy.members)-1]
+	delete(policy.indexes, member)
+}
+
+func (policy *randomPolicy[T]) Victim() T {
+	return policy.members[policy.random.Intn(len(policy.members))]
+}
@@ -0,0 +1,92 @@
+package goset
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBoundedSet(t *testing.T) {
+	t.Run("BoundedSet never exceeds its capacity", func(t *testing.T) {
+		set := NewBounded[int](3, nil)
+		set.Add(1, 2, 3, 4, 5)
+		expect(t, set.Count() == 3, "Expected Count 3, got %v", set.Count())
+		expect(t, set.Capacity() == 3, "Expected Capacity 3, got %v", set.Capacity())
+	})
+
+	t.Run("NewBounded panics for a capacity below 1", func(t *testing.T) {
+		defer func() {
+			expect(t, recover() != nil, "Expected NewBounded(0, ...) to panic")
+		}()
+		NewBounded[int](0, nil)
+	})
+
+	t.Run("LRU evicts the least recently used member", func(t *testing.T) {
+		set := NewBounded(3, NewLRU[string]())
+		set.Add("ryu", "ken", "guile")
+		set.Contains("ryu")
+		set.Add("vega")
+		expect(t, set.Snapshot().Equals(New("ryu", "guile", "vega")), "Expected ken to be evicted, got %s", set.Snapshot())
+	})
+
+	t.Run("FIFO evicts the oldest member regardless of use", func(t *testing.T) {
+		set := NewBounded(3, NewFIFO[string]())
+		set.Add("ryu", "ken", "guile")
+		set.Contains("ryu")
+		set.Add("ryu", "vega")
+		expect(t, set.Snapshot().Equals(New("ken", "guile", "vega")), "Expected ryu to be evicted, got %s", set.Snapshot())
+	})
+
+	t.Run("LFU evicts the least frequently used member", func(t *testing.T) {
+		set := NewBounded(3, NewLFU[string]())
+		set.Add("ryu", "ken", "guile")
+		set.Contains("ryu")
+		set.Contains("ryu")
+		set.Contains("guile")
+		set.Add("vega")
+		expect(t, set.Snapshot().Equals(New("ryu", "guile", "vega")), "Expected ken to be evicted, got %s", set.Snapshot())
+		set.Add("balrog")
+		expect(t, set.Snapshot().Equals(New("ryu", "guile", "balrog")), "Expected vega to be evicted, got %s", set.Snapshot())
+	})
+
+	t.Run("LFU copes with the least frequent member being removed", func(t *testing.T) {
+		set := NewBounded(2, NewLFU[int]())
+		set.Add(1, 2)
+		set.Contains(2)
+		set.Contains(2)
+		set.Remove(1)
+		set.Add(3)
+		set.Contains(3)
+		set.Add(4)
+		expect(t, set.Snapshot().Equals(New(2, 4)), "Expected 3 to be evicted, got %s", set.Snapshot())
+	})
+
+	t.Run("Random evicts a member", func(t *testing.T) {
+		set := NewBounded(10, NewRandom[int](rand.New(rand.NewSource(1))))
+		for member := 0; member < 100; member++ {
+			set.Add(member)
+		}
+		expect(t, set.Count() == 10, "Expected Count 10, got %v", set.Count())
+		expect(t, set.Contains(99), "Expected the latest member to be present")
+		set.Remove(99)
+		expect(t, set.Count() == 9, "Expected Count 9 after Remove, got %v", set.Count())
+	})
+
+	t.Run("OnEvict is called for each evicted member", func(t *testing.T) {
+		evicted := New[int]()
+		set := NewBounded(2, NewFIFO[int]()).OnEvict(func(member int) {
+			evicted.Add(member)
+		})
+		set.Add(1, 2, 3, 4)
+		expect(t, evicted.Equals(New(1, 2)), "Expected {1, 2} to be evicted, got %s", evicted)
+	})
+
+	t.Run("Stats counts hits, misses and evictions", func(t *testing.T) {
+		set := NewBounded[int](2, nil)
+		set.Add(1, 2, 3)
+		set.Contains(2, 3)
+		set.Contains(1)
+		stats := set.Stats()
+		expected := BoundedStats{Hits: 2, Misses: 1, Evictions: 1}
+		expect(t, stats == expected, "Expected %+v, got %+v", expected, stats)
+	})
+}
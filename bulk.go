@@ -0,0 +1,114 @@
+package goset
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+)
+
+// ProgressError reports an error that stopped a bulk operation part way through, and how far it got
+type ProgressError struct {
+	// Processed is the number of values or members dealt with before the operation stopped
+	Processed int
+	// Err is the cause, such as ctx.Err() or a parse error
+	Err error
+}
+
+// Error returns a description of theError
+func (theError *ProgressError) Error() string {
+	return fmt.Sprintf("goset: stopped after %d: %v", theError.Processed, theError.Err)
+}
+
+// Unwrap returns the cause of theError
+func (theError *ProgressError) Unwrap() error {
+	return theError.Err
+}
+
+// AddFromChannel adds each value received from ch to theSet until ch is closed, returning nil, or
+// until ctx is done, returning a *ProgressError wrapping ctx.Err(). Values received before ctx is
+// done remain in theSet.
+func (theSet Set[T]) AddFromChannel(ctx context.Context, ch <-chan T) error {
+	processed := 0
+	for {
+		// Check ctx first, as select chooses at random between a ready ch and a done ctx.
+		if err := ctx.Err(); err != nil {
+			return &ProgressError{Processed: processed, Err: err}
+		}
+		select {
+		case member, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			theSet.members[member] = exists
+			processed++
+		case <-ctx.Done():
+			return &ProgressError{Processed: processed, Err: ctx.Err()}
+		}
+	}
+}
+
+// AddFromReader adds the result of calling parse on each line read from r to theSet, until the end of r,
+// returning nil. It stops with a *ProgressError when ctx is done, or when parse or r return an error.
+// Members parsed before stopping remain in theSet. ctx is checked between lines, so a Read that
+// blocks is not interrupted.
+func (theSet Set[T]) AddFromReader(ctx context.Context, r io.Reader, parse func(line string) (T, error)) error {
+	scanner := bufio.NewScanner(r)
+	processed := 0
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return &ProgressError{Processed: processed, Err: err}
+		}
+		member, err := parse(scanner.Text())
+		if err != nil {
+			return &ProgressError{Processed: processed, Err: fmt.Errorf("line %d: %w", processed+1, err)}
+		}
+		theSet.members[member] = exists
+		processed++
+	}
+	if err := scanner.Err(); err != nil {
+		return &ProgressError{Processed: processed, Err: err}
+	}
+	return nil
+}
+
+// UnionContext returns the same result as Union, unless ctx is done first. It then returns the partial
+// union built so far, and a *ProgressError wrapping ctx.Err() counting the members of other processed.
+func (theSet Set[T]) UnionContext(ctx context.Context, other Set[T]) (Set[T], error) {
+	union := theSet.membersWithCapacity(theSet.Count() + other.Count())
+	processed := 0
+	for member := range other.members {
+		if processed%parallelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return union, &ProgressError{Processed: processed, Err: err}
+			}
+		}
+		union.members[member] = exists
+		processed++
+	}
+	return union, nil
+}
+
+// IntersectContext returns the same result as Intersect, unless ctx is done first. It then returns the
+// partial intersection found so far, and a *ProgressError wrapping ctx.Err() counting the members
+// of the smaller Set processed.
+func (theSet Set[T]) IntersectContext(ctx context.Context, other Set[T]) (Set[T], error) {
+	smaller, larger := theSet, other
+	if smaller.Count() > larger.Count() {
+		smaller, larger = larger, smaller
+	}
+	intersection := NewWithOptions(WithCapacity[T](smaller.Count()))
+	processed := 0
+	for member := range smaller.members {
+		if processed%parallelCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return intersection, &ProgressError{Processed: processed, Err: err}
+			}
+		}
+		if _, ok := larger.members[member]; ok {
+			intersection.members[member] = exists
+		}
+		processed++
+	}
+	return intersection, nil
+}
@@ -0,0 +1,133 @@
+package goset
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestSet_AddFromChannel(t *testing.T) {
+	t.Run("AddFromChannel adds values until the channel is closed", func(t *testing.T) {
+		ch := make(chan int, 4)
+		ch <- 1
+		ch <- 2
+		ch <- 2
+		close(ch)
+		set := New(0)
+		err := set.AddFromChannel(context.Background(), ch)
+		expect(t, err == nil, "Unexpected error %v", err)
+		expect(t, set.Equals(New(0, 1, 2)), "Expected {0, 1, 2}, got %s", set)
+	})
+
+	t.Run("AddFromChannel stops when the context is done, keeping progress", func(t *testing.T) {
+		ch := make(chan int)
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			ch <- 1
+			ch <- 2
+			cancel()
+		}()
+		set := New[int]()
+		err := set.AddFromChannel(ctx, ch)
+		var progress *ProgressError
+		expect(t, errors.As(err, &progress), "Expected a *ProgressError, got %v", err)
+		expect(t, errors.Is(err, context.Canceled), "Expected context.Canceled, got %v", err)
+		expect(t, progress != nil && progress.Processed == 2, "Expected 2 values processed, got %v", err)
+		expect(t, set.Equals(New(1, 2)), "Expected {1, 2}, got %s", set)
+	})
+
+	t.Run("AddFromChannel adds nothing when the context is done before the call", func(t *testing.T) {
+		ch := make(chan int, 100)
+		for value := 0; value < 100; value++ {
+			ch <- value
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		set := New[int]()
+		err := set.AddFromChannel(ctx, ch)
+		expect(t, errors.Is(err, context.Canceled), "Expected context.Canceled, got %v", err)
+		expect(t, set.Count() == 0, "Expected no values added, got %s", set)
+		expect(t, len(ch) == 100, "Expected no values received, got %d left", len(ch))
+	})
+}
+
+func TestSet_AddFromReader(t *testing.T) {
+	t.Run("AddFromReader adds each parsed line", func(t *testing.T) {
+		set := New[int]()
+		err := set.AddFromReader(context.Background(), strings.NewReader("3\n1\n3\n"), strconv.Atoi)
+		expect(t, err == nil, "Unexpected error %v", err)
+		expect(t, set.Equals(New(1, 3)), "Expected {1, 3}, got %s", set)
+	})
+
+	t.Run("AddFromReader reports the line of a parse error", func(t *testing.T) {
+		set := New[int]()
+		err := set.AddFromReader(context.Background(), strings.NewReader("3\n1\nthree\n4\n"), strconv.Atoi)
+		var progress *ProgressError
+		expect(t, errors.As(err, &progress), "Expected a *ProgressError, got %v", err)
+		expect(t, progress != nil && progress.Processed == 2, "Expected 2 lines processed, got %v", err)
+		expect(t, errors.Is(err, strconv.ErrSyntax), "Expected a syntax error, got %v", err)
+		expect(t, strings.Contains(err.Error(), "line 3"), "Expected the error to mention line 3, got %v", err)
+		expect(t, set.Equals(New(1, 3)), "Expected {1, 3}, got %s", set)
+	})
+
+	t.Run("AddFromReader reports read errors", func(t *testing.T) {
+		failure := errors.New("disk on fire")
+		set := New[string]()
+		err := set.AddFromReader(context.Background(), iotest.ErrReader(failure), func(line string) (string, error) { return line, nil })
+		expect(t, errors.Is(err, failure), "Expected the read error, got %v", err)
+	})
+
+	t.Run("AddFromReader stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		set := New[string]()
+		parse := func(line string) (string, error) {
+			if line == "stop" {
+				cancel()
+			}
+			return line, nil
+		}
+		err := set.AddFromReader(ctx, strings.NewReader("a\nstop\nb\n"), parse)
+		expect(t, errors.Is(err, context.Canceled), "Expected context.Canceled, got %v", err)
+		expect(t, set.Equals(New("a", "stop")), "Expected {a, stop}, got %s", set)
+	})
+}
+
+func TestSet_UnionContext(t *testing.T) {
+	a, b := New(1, 2), New(2, 3)
+
+	t.Run("UnionContext matches Union", func(t *testing.T) {
+		union, err := a.UnionContext(context.Background(), b)
+		expect(t, err == nil, "Unexpected error %v", err)
+		expect(t, union.Equals(a.Union(b)), "Expected %s, got %s", a.Union(b), union)
+	})
+
+	t.Run("UnionContext stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		union, err := a.UnionContext(ctx, b)
+		expect(t, errors.Is(err, context.Canceled), "Expected context.Canceled, got %v", err)
+		expect(t, union.IsSupersetOf(a), "Expected the partial union to contain a, got %s", union)
+	})
+}
+
+func TestSet_IntersectContext(t *testing.T) {
+	a, b := New(1, 2), New(2, 3)
+
+	t.Run("IntersectContext matches Intersect", func(t *testing.T) {
+		intersection, err := a.IntersectContext(context.Background(), b)
+		expect(t, err == nil, "Unexpected error %v", err)
+		expect(t, intersection.Equals(New(2)), "Expected {2}, got %s", intersection)
+	})
+
+	t.Run("IntersectContext stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		intersection, err := a.IntersectContext(ctx, b)
+		var progress *ProgressError
+		expect(t, errors.As(err, &progress) && progress.Processed == 0, "Expected nothing processed, got %v", err)
+		expect(t, intersection.Count() == 0, "Expected an empty partial intersection, got %s", intersection)
+	})
+}
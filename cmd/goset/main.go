@@ -0,0 +1,283 @@
+// Command goset performs set operations on line-based text files.
+//
+// Usage:
+//
+//	goset [flags] union|intersect|minus|symdiff file...
+//	goset [flags] equal|subset file...
+//
+// Each file is read into a set of strings, one member per line, or one per record with -csv or
+// -json. A file named "-" is read from standard input. Empty members are ignored.
+//
+// The set operations print the resulting members in sorted order, one per line:
+//
+//	union      members of any file
+//	intersect  members of every file
+//	minus      members of the first file that are in none of the others
+//	symdiff    members of an odd number of files
+//
+// The predicates print nothing, and exit with status 0 if true and 1 if false:
+//
+//	equal      every file has the same members
+//	subset     each file's members are a subset of the next file's
+//
+// Errors exit with status 2.
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/daynemay/goset"
+)
+
+const (
+	exitTrue  = 0
+	exitFalse = 1
+	exitError = 2
+)
+
+// validCommands holds the commands run accepts, checked before any files are read.
+var validCommands = map[string]bool{
+	"union": true, "intersect": true, "minus": true, "symdiff": true, "equal": true, "subset": true,
+}
+
+// options control how files are read into sets.
+type options struct {
+	foldCase  bool
+	trim      bool
+	csvColumn string
+	jsonField string
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line args, returning the exit status.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("goset", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: goset [flags] union|intersect|minus|symdiff|equal|subset file...")
+		flags.PrintDefaults()
+	}
+	var opts options
+	flags.BoolVar(&opts.foldCase, "i", false, "fold members to lower case")
+	flags.BoolVar(&opts.trim, "trim", false, "trim leading and trailing white space from members")
+	flags.StringVar(&opts.csvColumn, "csv", "", "read CSV, taking members from this column `number or header` name")
+	flags.StringVar(&opts.jsonField, "json", "", "read JSON objects, taking members from this `field`")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitTrue
+		}
+		return exitError
+	}
+	if flags.NArg() < 2 {
+		flags.Usage()
+		return exitError
+	}
+	if opts.csvColumn != "" && opts.jsonField != "" {
+		fmt.Fprintln(stderr, "goset: -csv and -json cannot be used together")
+		return exitError
+	}
+
+	command, files := flags.Arg(0), flags.Args()[1:]
+	if !validCommands[command] {
+		fmt.Fprintf(stderr, "goset: unknown command %q\n", command)
+		flags.Usage()
+		return exitError
+	}
+	sets := make([]goset.Set[string], 0, len(files))
+	for _, file := range files {
+		set, err := readFile(file, stdin, opts)
+		if err != nil {
+			fmt.Fprintf(stderr, "goset: %s: %v\n", file, err)
+			return exitError
+		}
+		sets = append(sets, set)
+	}
+
+	var result goset.Set[string]
+	switch command {
+	case "union":
+		result = sets[0]
+		for _, set := range sets[1:] {
+			result = result.Union(set)
+		}
+	case "intersect":
+		result = sets[0]
+		for _, set := range sets[1:] {
+			result = result.Intersect(set)
+		}
+	case "minus":
+		result = sets[0]
+		for _, set := range sets[1:] {
+			result = result.Minus(set)
+		}
+	case "symdiff":
+		result = sets[0]
+		for _, set := range sets[1:] {
+			result = result.Minus(set).Union(set.Minus(result))
+		}
+	case "equal":
+		return predicate(sets, goset.Set[string].Equals)
+	case "subset":
+		return predicate(sets, goset.Set[string].IsSubsetOf)
+	}
+
+	out := bufio.NewWriter(stdout)
+	for _, member := range result.AsSortedList() {
+		fmt.Fprintln(out, member)
+	}
+	if err := out.Flush(); err != nil {
+		fmt.Fprintf(stderr, "goset: %v\n", err)
+		return exitError
+	}
+	return exitTrue
+}
+
+// predicate returns exitTrue if holds is true for each set and the next, and exitFalse otherwise.
+func predicate(sets []goset.Set[string], holds func(a, b goset.Set[string]) bool) int {
+	for idx := 0; idx+1 < len(sets); idx++ {
+		if !holds(sets[idx], sets[idx+1]) {
+			return exitFalse
+		}
+	}
+	return exitTrue
+}
+
+// readFile reads the members of file, or of stdin if file is "-".
+func readFile(file string, stdin io.Reader, opts options) (goset.Set[string], error) {
+	if file == "-" {
+		return readSet(stdin, opts)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return goset.Set[string]{}, err
+	}
+	defer f.Close()
+	return readSet(f, opts)
+}
+
+// readSet reads members from r in the format chosen by opts.
+func readSet(r io.Reader, opts options) (goset.Set[string], error) {
+	set := goset.New[string]()
+	add := func(member string) {
+		if opts.trim {
+			member = strings.TrimSpace(member)
+		}
+		if opts.foldCase {
+			member = strings.ToLower(member)
+		}
+		if member != "" {
+			set.Add(member)
+		}
+	}
+
+	var err error
+	switch {
+	case opts.csvColumn != "":
+		err = readCSV(r, opts.csvColumn, add)
+	case opts.jsonField != "":
+		err = readJSON(r, opts.jsonField, add)
+	default:
+		err = readLines(r, add)
+	}
+	return set, err
+}
+
+func readLines(r io.Reader, add func(string)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		add(strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+	return scanner.Err()
+}
+
+// readCSV adds the given column of each record. A numeric column is 1-based; otherwise the first
+// record is a header naming the columns.
+func readCSV(r io.Reader, column string, add func(string)) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	idx, err := strconv.Atoi(column)
+	if err == nil {
+		if idx < 1 {
+			return fmt.Errorf("invalid CSV column %d", idx)
+		}
+		idx--
+	} else {
+		header, err := reader.Read()
+		if err != nil {
+			return err
+		}
+		idx = -1
+		for i, name := range header {
+			if name == column {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return fmt.Errorf("no CSV column named %q", column)
+		}
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if idx < len(record) {
+			add(record[idx])
+		}
+	}
+}
+
+// readJSON adds the given field of each object, where the input is a sequence of JSON objects
+// and arrays of objects, such as JSON Lines. Non-string values are added in their JSON form.
+func readJSON(r io.Reader, field string, add func(string)) error {
+	decoder := json.NewDecoder(r)
+	addObject := func(object map[string]json.RawMessage) {
+		value, ok := object[field]
+		if !ok {
+			return
+		}
+		var s string
+		if json.Unmarshal(value, &s) == nil {
+			add(s)
+		} else if string(value) != "null" {
+			add(string(value))
+		}
+	}
+	for {
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		var objects []map[string]json.RawMessage
+		if err := json.Unmarshal(value, &objects); err == nil {
+			for _, object := range objects {
+				addObject(object)
+			}
+			continue
+		}
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(value, &object); err != nil {
+			return fmt.Errorf("expected a JSON object or array of objects: %v", err)
+		}
+		addObject(object)
+	}
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func expect(t *testing.T, condition bool, description string, subs ...interface{}) {
+	if !condition {
+		t.Errorf(description, subs...)
+	}
+}
+
+// writeFiles writes each of contents to a temporary file, returning their paths.
+func writeFiles(t *testing.T, contents ...string) []string {
+	dir := t.TempDir()
+	paths := []string{}
+	for idx, content := range contents {
+		path := filepath.Join(dir, string(rune('a'+idx))+".txt")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func runCommand(stdin string, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	status := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	return status, stdout.String(), stderr.String()
+}
+
+func TestRun_operations(t *testing.T) {
+	files := writeFiles(t, "ryu\nken\nguile\n", "ken\nguile\nvega\n\n", "guile\nbalrog\n")
+	cases := map[string]string{
+		"union":     "balrog\nguile\nken\nryu\nvega\n",
+		"intersect": "guile\n",
+		"minus":     "ryu\n",
+		"symdiff":   "balrog\nguile\nryu\nvega\n",
+	}
+	for command, expected := range cases {
+		status, stdout, stderr := runCommand("", append([]string{command}, files...)...)
+		expect(t, status == 0, "%s: expected status 0, got %v (%s)", command, status, stderr)
+		expect(t, stdout == expected, "%s: expected %q, got %q", command, expected, stdout)
+	}
+}
+
+func TestRun_predicates(t *testing.T) {
+	files := writeFiles(t, "ryu\nken\n", "ken\r\nryu\r\n", "ken\nryu\nguile\n")
+
+	status, _, _ := runCommand("", "equal", files[0], files[1])
+	expect(t, status == 0, "Expected equal files to exit 0, got %v", status)
+	status, _, _ = runCommand("", "equal", files[0], files[2])
+	expect(t, status == 1, "Expected unequal files to exit 1, got %v", status)
+	status, _, _ = runCommand("", "subset", files[0], files[2])
+	expect(t, status == 0, "Expected subset to exit 0, got %v", status)
+	status, _, _ = runCommand("", "subset", files[2], files[0])
+	expect(t, status == 1, "Expected non-subset to exit 1, got %v", status)
+}
+
+func TestRun_options(t *testing.T) {
+	t.Run("-i and -trim normalize members", func(t *testing.T) {
+		files := writeFiles(t, "  Ryu \nKEN\n", "ryu\nken\n")
+		status, _, _ := runCommand("", append([]string{"equal"}, files...)...)
+		expect(t, status == 1, "Expected files to differ without options")
+		status, _, stderr := runCommand("", "-i", "-trim", "equal", files[0], files[1])
+		expect(t, status == 0, "Expected files to be equal with -i -trim, got %v (%s)", status, stderr)
+	})
+
+	t.Run("- reads from stdin", func(t *testing.T) {
+		files := writeFiles(t, "ryu\n")
+		status, stdout, _ := runCommand("ken\n", "union", "-", files[0])
+		expect(t, status == 0 && stdout == "ken\nryu\n", "Expected union with stdin, got %v %q", status, stdout)
+	})
+
+	t.Run("-csv reads a numbered column", func(t *testing.T) {
+		files := writeFiles(t, "1,ryu\n2,ken\n", "9,ken\n")
+		status, stdout, stderr := runCommand("", "-csv", "2", "minus", files[0], files[1])
+		expect(t, status == 0 && stdout == "ryu\n", "Expected ryu, got %v %q (%s)", status, stdout, stderr)
+	})
+
+	t.Run("-csv reads a named column", func(t *testing.T) {
+		files := writeFiles(t, "id,name\n1,\"ryu, hoshi\"\n2,ken\n")
+		status, stdout, stderr := runCommand("", "-csv", "name", "union", files[0], files[0])
+		expect(t, status == 0 && stdout == "ken\nryu, hoshi\n", "Expected names, got %v %q (%s)", status, stdout, stderr)
+		status, _, stderr = runCommand("", "-csv", "missing", "union", files[0], files[0])
+		expect(t, status == 2 && strings.Contains(stderr, "missing"), "Expected an error for a missing column, got %v %q", status, stderr)
+	})
+
+	t.Run("-json reads a field from objects and arrays of objects", func(t *testing.T) {
+		files := writeFiles(t, "{\"name\": \"ryu\"}\n{\"name\": \"ken\", \"age\": 3}\n{\"age\": 4}\n", "[{\"name\": \"ken\"}, {\"name\": 7}]")
+		status, stdout, stderr := runCommand("", append([]string{"-json", "name", "symdiff"}, files...)...)
+		expect(t, status == 0 && stdout == "7\nryu\n", "Expected 7 and ryu, got %v %q (%s)", status, stdout, stderr)
+	})
+}
+
+func TestRun_errors(t *testing.T) {
+	files := writeFiles(t, "ryu\n")
+	cases := [][]string{
+		{"union"},
+		{"frobnicate", files[0], files[0]},
+		{"union", files[0], filepath.Join(t.TempDir(), "missing.txt")},
+		{"-csv", "1", "-json", "name", "union", files[0], files[0]},
+		{"-nope", "union", files[0]},
+	}
+	for _, args := range cases {
+		status, _, stderr := runCommand("", args...)
+		expect(t, status == 2, "%v: expected status 2, got %v", args, status)
+		expect(t, stderr != "", "%v: expected a message on stderr", args)
+	}
+}
+
+// unreadable is an io.Reader that fails the test if it is read.
+type unreadable struct{ t *testing.T }
+
+func (r unreadable) Read([]byte) (int, error) {
+	r.t.Error("Expected input not to be read")
+	return 0, io.EOF
+}
+
+func TestRun_unknownCommandReadsNothing(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	status := run([]string{"unoin", "-", "-"}, unreadable{t}, &stdout, &stderr)
+	expect(t, status == 2, "Expected status 2, got %v", status)
+	expect(t, strings.Contains(stderr.String(), `unknown command "unoin"`), "Expected an unknown command message, got %q", stderr.String())
+}
@@ -0,0 +1,71 @@
+package goset
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// A Codec converts members to and from bytes, for storing them on disk. Members that are == must
+// encode to the same bytes, and Decode must return a member == to the one encoded.
+type Codec[T comparable] interface {
+	Encode(member T) ([]byte, error)
+	Decode(data []byte) (T, error)
+}
+
+// StringCodec returns a Codec storing strings as their raw bytes
+func StringCodec() Codec[string] {
+	return stringCodec{}
+}
+
+type stringCodec struct{}
+
+func (stringCodec) Encode(member string) ([]byte, error) { return []byte(member), nil }
+func (stringCodec) Decode(data []byte) (string, error)   { return string(data), nil }
+
+// JSONCodec returns a Codec storing members as JSON, using encoding/json
+func JSONCodec[T comparable]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+type jsonCodec[T comparable] struct{}
+
+func (jsonCodec[T]) Encode(member T) ([]byte, error) { return json.Marshal(member) }
+
+func (jsonCodec[T]) Decode(data []byte) (T, error) {
+	var member T
+	err := json.Unmarshal(data, &member)
+	return member, err
+}
+
+// writeRecord writes data to w prefixed by its length, returning the number of bytes written.
+func writeRecord(w io.Writer, data []byte) (int, error) {
+	var prefix [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(prefix[:], uint64(len(data)))
+	if _, err := w.Write(prefix[:n]); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return 0, err
+	}
+	return n + len(data), nil
+}
+
+// readRecord reads a record written by writeRecord. It returns io.EOF only if r is at the end of its
+// input before the record starts, and io.ErrUnexpectedEOF if the record is cut short.
+func readRecord(r *bufio.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,58 @@
+package goset
+
+import "cmp"
+
+// A CompareFunc returns a negative number when a < b, a positive number when a > b and zero when a == b,
+// in the style of cmp.Compare, and may be passed directly to slices.SortFunc.
+type CompareFunc[T comparable] func(a, b T) int
+
+// CompareFunc returns the three-way CompareFunc equivalent to less
+func (less Comparator[T]) CompareFunc() CompareFunc[T] {
+	return func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	}
+}
+
+// Comparator returns the less-than Comparator equivalent to compare
+func (compare CompareFunc[T]) Comparator() Comparator[T] {
+	return func(a, b T) bool {
+		return compare(a, b) < 0
+	}
+}
+
+// Reverse returns a Comparator ordering members in the opposite order to less
+func Reverse[T comparable](less Comparator[T]) Comparator[T] {
+	return func(a, b T) bool {
+		return less(b, a)
+	}
+}
+
+// ThenBy returns a Comparator ordering members by less, breaking ties with each of then in turn
+func ThenBy[T comparable](less Comparator[T], then ...Comparator[T]) Comparator[T] {
+	chain := append([]Comparator[T]{less}, then...)
+	return func(a, b T) bool {
+		for _, next := range chain {
+			switch {
+			case next(a, b):
+				return true
+			case next(b, a):
+				return false
+			}
+		}
+		return false
+	}
+}
+
+// ByKey returns a Comparator ordering members by the ordered key extracted from each of them
+func ByKey[T comparable, K cmp.Ordered](key func(T) K) Comparator[T] {
+	return func(a, b T) bool {
+		return cmp.Less(key(a), key(b))
+	}
+}
@@ -0,0 +1,88 @@
+package goset
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// A CompareFunc (see comparator.go) to order a collection of Person by age.
+func comparePersonAge(a, b person) int {
+	return cmp.Compare(a.age, b.age)
+}
+
+func TestNewWithCompareFunc(t *testing.T) {
+	t.Run("NewWithCompareFunc will accept a nil CompareFunc", func(t *testing.T) {
+		set := NewWithCompareFunc[person](nil, people...)
+		count := set.Count()
+		expected := len(people)
+		expect(t, count == expected, "NewWithCompareFunc(nil).Count() = %v, expected %v", count, expected)
+	})
+
+	t.Run("AsSortedList will respect a supplied CompareFunc", func(t *testing.T) {
+		set := NewWithCompareFunc(comparePersonAge, people...)
+		sorted := set.AsSortedList()
+		expected := []person{kim, greg, chris, lara, rick, jeff}
+		expect(t, reflect.DeepEqual(sorted, expected), "Set comparePersonAge = %v, expected %v", sorted, expected)
+	})
+}
+
+func TestComparator_CompareFunc(t *testing.T) {
+	compare := Comparator[person](byPersonAge).CompareFunc()
+
+	t.Run("CompareFunc orders like the Comparator it adapts", func(t *testing.T) {
+		expect(t, compare(kim, jeff) < 0, "Expected kim < jeff")
+		expect(t, compare(jeff, kim) > 0, "Expected jeff > kim")
+		expect(t, compare(kim, person{"Kimberly", 3}) == 0, "Expected people of the same age to compare equal")
+	})
+
+	t.Run("CompareFunc can be used with slices.SortFunc", func(t *testing.T) {
+		sorted := slices.Clone(people)
+		slices.SortFunc(sorted, compare)
+		expected := []person{kim, greg, chris, lara, rick, jeff}
+		expect(t, reflect.DeepEqual(sorted, expected), "Expected %v, got %v", expected, sorted)
+	})
+
+	t.Run("Comparator round-trips through CompareFunc", func(t *testing.T) {
+		less := compare.Comparator()
+		expect(t, less(kim, jeff), "Expected kim < jeff")
+		expect(t, !less(jeff, kim), "Expected !(jeff < kim)")
+		expect(t, !less(kim, kim), "Expected !(kim < kim)")
+	})
+}
+
+func TestReverse(t *testing.T) {
+	t.Run("Reverse orders members in the opposite order", func(t *testing.T) {
+		set := NewWithComparator(Reverse(byPersonAge), people...)
+		sorted := set.AsSortedList()
+		expected := []person{jeff, rick, lara, chris, greg, kim}
+		expect(t, reflect.DeepEqual(sorted, expected), "Expected %v, got %v", expected, sorted)
+	})
+}
+
+func TestThenBy(t *testing.T) {
+	byNameLength := ByKey(func(p person) int { return len(p.name) })
+
+	t.Run("ThenBy breaks ties using the following Comparators", func(t *testing.T) {
+		set := NewWithComparator(ThenBy(byNameLength, byPersonAge), people...)
+		sorted := set.AsSortedList()
+		expected := []person{kim, greg, lara, rick, jeff, chris}
+		expect(t, reflect.DeepEqual(sorted, expected), "Expected %v, got %v", expected, sorted)
+	})
+
+	t.Run("ThenBy with no further Comparators behaves like the first", func(t *testing.T) {
+		less := ThenBy(byPersonAge)
+		expect(t, less(kim, jeff) && !less(jeff, kim), "Expected ThenBy(byPersonAge) to order kim before jeff")
+	})
+}
+
+func TestByKey(t *testing.T) {
+	t.Run("ByKey orders members by the extracted key", func(t *testing.T) {
+		set := NewWithComparator(ByKey(func(p person) string { return strings.ToLower(p.name) }), people...)
+		sorted := set.AsSortedList()
+		expected := []person{chris, greg, jeff, kim, lara, rick}
+		expect(t, reflect.DeepEqual(sorted, expected), "Expected %v, got %v", expected, sorted)
+	})
+}
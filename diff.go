@@ -0,0 +1,84 @@
+package goset
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetDiff describes the changes between two Sets, as returned by Diff
+type SetDiff[T comparable] struct {
+	// Added holds the members present only in the new Set
+	Added Set[T]
+	// Removed holds the members present only in the old Set
+	Removed Set[T]
+	// Unchanged holds the members present in both Sets
+	Unchanged Set[T]
+}
+
+// Diff returns a SetDiff describing the changes needed to turn oldSet into newSet.
+// The resulting Sets share oldSet's Comparator.
+func Diff[T comparable](oldSet, newSet Set[T]) SetDiff[T] {
+	diff := SetDiff[T]{
+		Added:     NewWithComparator(oldSet.comparator),
+		Removed:   NewWithComparator(oldSet.comparator),
+		Unchanged: NewWithComparator(oldSet.comparator),
+	}
+	for member := range oldSet.members {
+		if newSet.Contains(member) {
+			diff.Unchanged.Add(member)
+		} else {
+			diff.Removed.Add(member)
+		}
+	}
+	for member := range newSet.members {
+		if !oldSet.Contains(member) {
+			diff.Added.Add(member)
+		}
+	}
+	return diff
+}
+
+// IsEmpty returns a boolean indicating whether theDiff has no Added or Removed members
+func (theDiff SetDiff[T]) IsEmpty() bool {
+	return theDiff.Added.Count() == 0 && theDiff.Removed.Count() == 0
+}
+
+// Apply patches set in place by removing theDiff's Removed members and adding its Added members,
+// and returns the modified set
+func (theDiff SetDiff[T]) Apply(set Set[T]) Set[T] {
+	set.Remove(theDiff.Removed.AsList()...)
+	set.Add(theDiff.Added.AsList()...)
+	return set
+}
+
+// Invert returns a SetDiff undoing theDiff, i.e. with Added and Removed swapped
+func (theDiff SetDiff[T]) Invert() SetDiff[T] {
+	return SetDiff[T]{
+		Added:     theDiff.Removed.cloneWithComparator(),
+		Removed:   theDiff.Added.cloneWithComparator(),
+		Unchanged: theDiff.Unchanged.cloneWithComparator(),
+	}
+}
+
+// String returns a unified-diff style representation of theDiff, with one member per line in
+// AsSortedList order, prefixed by "+" when Added, "-" when Removed and " " when Unchanged
+func (theDiff SetDiff[T]) String() string {
+	var sb strings.Builder
+	all := NewWithComparator(theDiff.Unchanged.comparator)
+	all.Add(theDiff.Added.AsList()...)
+	all.Add(theDiff.Removed.AsList()...)
+	all.Add(theDiff.Unchanged.AsList()...)
+
+	for _, member := range all.AsSortedList() {
+		switch {
+		case theDiff.Added.Contains(member):
+			sb.WriteString("+")
+		case theDiff.Removed.Contains(member):
+			sb.WriteString("-")
+		default:
+			sb.WriteString(" ")
+		}
+		sb.WriteString(fmt.Sprintf("%v\n", member))
+	}
+	return sb.String()
+}
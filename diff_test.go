@@ -0,0 +1,51 @@
+package goset
+
+import "testing"
+
+func TestDiff(t *testing.T) {
+	oldSet := New("ryu", "ken", "guile")
+	newSet := New("ken", "guile", "cammy", "balrog")
+
+	t.Run("Diff reports Added, Removed and Unchanged members", func(t *testing.T) {
+		diff := Diff(oldSet, newSet)
+		expect(t, diff.Added.Equals(New("cammy", "balrog")), "Expected Added = {balrog, cammy}, got %s", diff.Added)
+		expect(t, diff.Removed.Equals(New("ryu")), "Expected Removed = {ryu}, got %s", diff.Removed)
+		expect(t, diff.Unchanged.Equals(New("ken", "guile")), "Expected Unchanged = {guile, ken}, got %s", diff.Unchanged)
+	})
+
+	t.Run("Diff of equal sets IsEmpty", func(t *testing.T) {
+		diff := Diff(oldSet, oldSet.Clone())
+		expect(t, diff.IsEmpty(), "Expected no changes between equal sets")
+		expect(t, diff.Unchanged.Equals(oldSet), "Expected all members to be Unchanged")
+		expect(t, !Diff(oldSet, newSet).IsEmpty(), "Expected changes between different sets")
+	})
+
+	t.Run("Apply turns the old set into the new set", func(t *testing.T) {
+		patched := Diff(oldSet, newSet).Apply(oldSet.Clone())
+		expect(t, patched.Equals(newSet), "Expected %s, got %s", newSet, patched)
+	})
+
+	t.Run("Apply of the Invert turns the new set into the old set", func(t *testing.T) {
+		patched := Diff(oldSet, newSet).Invert().Apply(newSet.Clone())
+		expect(t, patched.Equals(oldSet), "Expected %s, got %s", oldSet, patched)
+	})
+
+	t.Run("Invert does not share Sets with the original diff", func(t *testing.T) {
+		diff := Diff(oldSet, newSet)
+		inverted := diff.Invert()
+		inverted.Added.Add("vega")
+		expect(t, !diff.Removed.Contains("vega"), "Expected Invert to copy members")
+	})
+
+	t.Run("String shows a unified-diff style listing in sorted order", func(t *testing.T) {
+		actual := Diff(oldSet, newSet).String()
+		expected := "+balrog\n+cammy\n guile\n ken\n-ryu\n"
+		expect(t, actual == expected, "Expected:\n%s\ngot:\n%s", expected, actual)
+	})
+
+	t.Run("String respects the Comparator of the old set", func(t *testing.T) {
+		actual := Diff(NewWithComparator(byPersonAge, jeff, kim), NewWithComparator(byPersonAge, kim, greg)).String()
+		expected := " {Kim 3}\n+{Greg 45}\n-{Jeff 58}\n"
+		expect(t, actual == expected, "Expected:\n%s\ngot:\n%s", expected, actual)
+	})
+}
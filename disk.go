@@ -0,0 +1,400 @@
+package goset
+
+import (
+	"bufio"
+	"container/heap"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"slices"
+	"sort"
+)
+
+// diskIndexInterval is how many members of a run lie between the entries of its in-memory index.
+const diskIndexInterval = 64
+
+// diskFalsePositiveRate is the rate at which a run's bloom filter sends Contains to disk needlessly.
+const diskFalsePositiveRate = 0.01
+
+// DiskSet is a set too large to hold in memory. Members are added to an in-memory Set until it holds
+// memoryLimit members, when they are written out to a temporary file as a sorted run. Runs hold
+// disjoint members, and each keeps a bloom filter and a sparse index in memory so that Contains
+// usually reads at most one small section of one run.
+// Union, Intersect and Minus merge sorted streams of members, so hold in memory at most memoryLimit
+// members of the result, the sorted in-memory members of both inputs and the index of every run,
+// rather than every member. A DiskSet is not safe for concurrent use, and should be closed with
+// Close to remove its files.
+type DiskSet[T comparable] struct {
+	dir     string
+	limit   int
+	codec   Codec[T]
+	compare CompareFunc[T]
+	memory  Set[T]
+	runs    []*diskRun[T]
+	// err is the error that stopped the most recent iteration of Sorted.
+	err error
+}
+
+// diskRun is a file of members in sorted order, each written with writeRecord.
+type diskRun[T comparable] struct {
+	file    *os.File
+	size    int64
+	count   int
+	filter  *bloomFilter
+	keys    []T
+	offsets []int64
+}
+
+// NewDiskSet returns a new, empty DiskSet holding at most memoryLimit members in memory, and writing
+// runs encoded by codec to temporary files in dir, or the default directory for temporary files if
+// dir is empty. Runs are sorted by compare, or by the default ordering of AsSortedList if compare is nil;
+// compare must return zero only for members that are ==. NewDiskSet panics if memoryLimit is less than 1.
+func NewDiskSet[T comparable](dir string, memoryLimit int, codec Codec[T], compare CompareFunc[T]) *DiskSet[T] {
+	if memoryLimit < 1 {
+		panic("goset: DiskSet memory limit must be at least 1")
+	}
+	if compare == nil {
+		compare = memberCompareFunc[T]()
+	}
+	return &DiskSet[T]{
+		dir:     dir,
+		limit:   memoryLimit,
+		codec:   codec,
+		compare: compare,
+		memory:  New[T](),
+	}
+}
+
+// Add adds members to theSet, writing a run to disk each time memoryLimit members are held in memory
+func (theSet *DiskSet[T]) Add(members ...T) error {
+	for _, member := range members {
+		found, err := theSet.Contains(member)
+		if err != nil {
+			return err
+		}
+		if found {
+			continue
+		}
+		theSet.memory.Add(member)
+		if theSet.memory.Count() >= theSet.limit {
+			if err := theSet.spill(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Contains returns a boolean indicating whether theSet contains all the given values
+func (theSet *DiskSet[T]) Contains(values ...T) (bool, error) {
+	for _, value := range values {
+		if theSet.memory.Contains(value) {
+			continue
+		}
+		found := false
+		for _, run := range theSet.runs {
+			var err error
+			if found, err = run.contains(theSet.codec, theSet.compare, value); err != nil {
+				return false, err
+			}
+			if found {
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Count returns the number of members in theSet
+func (theSet *DiskSet[T]) Count() int {
+	count := theSet.memory.Count()
+	for _, run := range theSet.runs {
+		count += run.count
+	}
+	return count
+}
+
+// Runs returns the number of runs theSet has written to disk
+func (theSet *DiskSet[T]) Runs() int {
+	return len(theSet.runs)
+}
+
+// Sorted returns an iterator over the members of theSet in sorted order. It stops early if reading a run
+// fails, leaving the error to be returned by Err. theSet must not be modified during iteration.
+func (theSet *DiskSet[T]) Sorted() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		theSet.err = nil
+		theSet.sorted(&theSet.err)(yield)
+	}
+}
+
+// Err returns the error that stopped the most recent iteration of an iterator returned from Sorted, or nil
+func (theSet *DiskSet[T]) Err() error {
+	return theSet.err
+}
+
+// Union returns a new DiskSet, with the same settings as theSet, of the members of theSet or other.
+// other must be sorted by the same ordering as theSet.
+func (theSet *DiskSet[T]) Union(other *DiskSet[T]) (*DiskSet[T], error) {
+	var err error
+	return theSet.merged(StreamUnion(theSet.compare, theSet.sorted(&err), other.sorted(&err)), &err)
+}
+
+// Intersect returns a new DiskSet, with the same settings as theSet, of the members of both theSet and
+// other. other must be sorted by the same ordering as theSet.
+func (theSet *DiskSet[T]) Intersect(other *DiskSet[T]) (*DiskSet[T], error) {
+	var err error
+	return theSet.merged(StreamIntersect(theSet.compare, theSet.sorted(&err), other.sorted(&err)), &err)
+}
+
+// Minus returns a new DiskSet, with the same settings as theSet, of the members of theSet that are not
+// in other. other must be sorted by the same ordering as theSet.
+func (theSet *DiskSet[T]) Minus(other *DiskSet[T]) (*DiskSet[T], error) {
+	var err error
+	return theSet.merged(StreamMinus(theSet.compare, theSet.sorted(&err), other.sorted(&err)), &err)
+}
+
+// Close removes the files of theSet's runs, after which theSet is empty
+func (theSet *DiskSet[T]) Close() error {
+	var errs []error
+	for _, run := range theSet.runs {
+		errs = append(errs, run.file.Close(), os.Remove(run.file.Name()))
+	}
+	theSet.runs = nil
+	theSet.memory = New[T]()
+	return errors.Join(errs...)
+}
+
+// merged returns a new DiskSet of the sorted, distinct members of seq, written out a run at a time. seqErr
+// points to the error, if any, that stopped seq early.
+func (theSet *DiskSet[T]) merged(seq iter.Seq[T], seqErr *error) (*DiskSet[T], error) {
+	result := NewDiskSet(theSet.dir, theSet.limit, theSet.codec, theSet.compare)
+	chunk := make([]T, 0, theSet.limit)
+	var err error
+	for member := range seq {
+		chunk = append(chunk, member)
+		if len(chunk) == theSet.limit {
+			if err = result.writeRun(chunk); err != nil {
+				break
+			}
+			chunk = chunk[:0]
+		}
+	}
+	if err == nil && len(chunk) > 0 {
+		err = result.writeRun(chunk)
+	}
+	if err = errors.Join(err, *seqErr); err != nil {
+		return nil, errors.Join(err, result.Close())
+	}
+	return result, nil
+}
+
+// spill writes the members held in memory to a new run.
+func (theSet *DiskSet[T]) spill() error {
+	if err := theSet.writeRun(theSet.sortedMemory()); err != nil {
+		return err
+	}
+	theSet.memory = New[T]()
+	return nil
+}
+
+// sortedMemory returns the members held in memory, sorted.
+func (theSet *DiskSet[T]) sortedMemory() []T {
+	members := theSet.memory.AsList()
+	slices.SortFunc(members, theSet.compare)
+	return members
+}
+
+// writeRun writes sorted, distinct members, none of which are in an existing run, to a new run.
+func (theSet *DiskSet[T]) writeRun(members []T) (err error) {
+	file, err := os.CreateTemp(theSet.dir, "goset-run-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, file.Close(), os.Remove(file.Name()))
+		}
+	}()
+
+	run := &diskRun[T]{
+		file:   file,
+		count:  len(members),
+		filter: newBloomFilter(len(members), diskFalsePositiveRate),
+	}
+	w := bufio.NewWriter(file)
+	for idx, member := range members {
+		data, err := theSet.codec.Encode(member)
+		if err != nil {
+			return err
+		}
+		if idx%diskIndexInterval == 0 {
+			run.keys = append(run.keys, member)
+			run.offsets = append(run.offsets, run.size)
+		}
+		n, err := writeRecord(w, data)
+		if err != nil {
+			return err
+		}
+		run.size += int64(n)
+		run.filter.add(member)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	theSet.runs = append(theSet.runs, run)
+	return nil
+}
+
+// sorted returns an iterator over the members of theSet in sorted order, stopping early and recording
+// the error in *errp, if *errp is nil, if reading a run fails. The members held in memory and the runs
+// are merged through a heap, so each member costs O(log runs) comparisons.
+func (theSet *DiskSet[T]) sorted(errp *error) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		fail := func(err error) {
+			if *errp == nil {
+				*errp = err
+			}
+		}
+		memory := theSet.sortedMemory()
+		sources := []func() (T, bool, error){func() (T, bool, error) {
+			var member T
+			if len(memory) == 0 {
+				return member, false, nil
+			}
+			member, memory = memory[0], memory[1:]
+			return member, true, nil
+		}}
+		for _, run := range theSet.runs {
+			sources = append(sources, run.reader(theSet.codec))
+		}
+
+		cursors := &diskCursors[T]{compare: theSet.compare}
+		for _, next := range sources {
+			member, ok, err := next()
+			if err != nil {
+				fail(err)
+				return
+			}
+			if ok {
+				cursors.items = append(cursors.items, &diskCursor[T]{member: member, next: next})
+			}
+		}
+		heap.Init(cursors)
+		for cursors.Len() > 0 {
+			top := cursors.items[0]
+			if !yield(top.member) {
+				return
+			}
+			member, ok, err := top.next()
+			if err != nil {
+				fail(err)
+				return
+			}
+			if ok {
+				top.member = member
+				heap.Fix(cursors, 0)
+			} else {
+				heap.Pop(cursors)
+			}
+		}
+	}
+}
+
+// diskCursor is the current member of a sorted source of members, and the function reading its next.
+type diskCursor[T comparable] struct {
+	member T
+	next   func() (T, bool, error)
+}
+
+// diskCursors is a heap of cursors ordered by their current members.
+type diskCursors[T comparable] struct {
+	compare CompareFunc[T]
+	items   []*diskCursor[T]
+}
+
+func (cursors *diskCursors[T]) Len() int { return len(cursors.items) }
+
+func (cursors *diskCursors[T]) Less(i, j int) bool {
+	return cursors.compare(cursors.items[i].member, cursors.items[j].member) < 0
+}
+
+func (cursors *diskCursors[T]) Swap(i, j int) {
+	cursors.items[i], cursors.items[j] = cursors.items[j], cursors.items[i]
+}
+
+func (cursors *diskCursors[T]) Push(x any) {
+	cursors.items = append(cursors.items, x.(*diskCursor[T]))
+}
+
+func (cursors *diskCursors[T]) Pop() any {
+	last := cursors.items[len(cursors.items)-1]
+	cursors.items = cursors.items[:len(cursors.items)-1]
+	return last
+}
+
+// reader returns a function reading the members of run in turn, returning false at the end of the run.
+func (run *diskRun[T]) reader(codec Codec[T]) func() (T, bool, error) {
+	r := bufio.NewReader(io.NewSectionReader(run.file, 0, run.size))
+	return func() (T, bool, error) {
+		var member T
+		data, err := readRecord(r)
+		if err == io.EOF {
+			return member, false, nil
+		}
+		if err == nil {
+			member, err = codec.Decode(data)
+		}
+		return member, err == nil, err
+	}
+}
+
+// contains returns whether run holds value, consulting the filter and then the one section of the run
+// that the index shows could hold it.
+func (run *diskRun[T]) contains(codec Codec[T], compare CompareFunc[T], value T) (bool, error) {
+	if !run.filter.mayContain(value) {
+		return false, nil
+	}
+	section := sort.Search(len(run.keys), func(i int) bool {
+		return compare(run.keys[i], value) > 0
+	}) - 1
+	if section < 0 {
+		return false, nil
+	}
+	end := run.size
+	if section+1 < len(run.offsets) {
+		end = run.offsets[section+1]
+	}
+	found := false
+	err := run.scan(codec, run.offsets[section], end, func(member T) bool {
+		order := compare(member, value)
+		found = order == 0
+		return order < 0
+	})
+	return found, err
+}
+
+// scan decodes the members of run between offsets start and end, passing each to yield until it returns false.
+func (run *diskRun[T]) scan(codec Codec[T], start, end int64, yield func(T) bool) error {
+	r := bufio.NewReader(io.NewSectionReader(run.file, start, end-start))
+	for {
+		data, err := readRecord(r)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		member, err := codec.Decode(data)
+		if err != nil {
+			return err
+		}
+		if !yield(member) {
+			return nil
+		}
+	}
+}
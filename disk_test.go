@@ -0,0 +1,180 @@
+package goset
+
+import (
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// newTestDiskSet returns a DiskSet of the given ints, closed when the test finishes.
+func newTestDiskSet(t *testing.T, memoryLimit int, members ...int) *DiskSet[int] {
+	t.Helper()
+	set := NewDiskSet(t.TempDir(), memoryLimit, JSONCodec[int](), nil)
+	t.Cleanup(func() { set.Close() })
+	if err := set.Add(members...); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return set
+}
+
+func TestDiskSet(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	members := make([]int, 1000)
+	for idx := range members {
+		members[idx] = random.Intn(2000)
+	}
+	expected := New(members...)
+
+	t.Run("DiskSet spills to runs on disk and still holds every member", func(t *testing.T) {
+		set := newTestDiskSet(t, 100, members...)
+		expect(t, set.Runs() > 1, "Expected several runs, got %d", set.Runs())
+		expect(t, set.Count() == expected.Count(), "Expected Count %d, got %d", expected.Count(), set.Count())
+		for value := -10; value < 2010; value++ {
+			found, err := set.Contains(value)
+			expect(t, err == nil, "Unexpected error %v", err)
+			expect(t, found == expected.Contains(value), "Expected Contains(%d) to be %t", value, expected.Contains(value))
+		}
+	})
+
+	t.Run("DiskSet Sorted yields every member in order", func(t *testing.T) {
+		set := newTestDiskSet(t, 64, members...)
+		sorted := slices.Collect(set.Sorted())
+		expect(t, set.Err() == nil, "Unexpected error %v", set.Err())
+		expect(t, slices.Equal(sorted, expected.AsSortedList()), "Expected the sorted members, got %v", sorted)
+	})
+
+	t.Run("DiskSet merges many runs in order", func(t *testing.T) {
+		set := newTestDiskSet(t, 2, members...)
+		expect(t, set.Runs() > 300, "Expected hundreds of runs, got %d", set.Runs())
+		sorted := slices.Collect(set.Sorted())
+		expect(t, set.Err() == nil, "Unexpected error %v", set.Err())
+		expect(t, slices.Equal(sorted, expected.AsSortedList()), "Expected the sorted members, got %v", sorted)
+	})
+
+	t.Run("DiskSet Contains requires all values", func(t *testing.T) {
+		set := newTestDiskSet(t, 2, 1, 2, 3, 4, 5)
+		found, _ := set.Contains(1, 5)
+		expect(t, found, "Expected DiskSet to contain 1 and 5")
+		found, _ = set.Contains(1, 6)
+		expect(t, !found, "Expected DiskSet not to contain 1 and 6")
+	})
+
+	operations := map[string]struct {
+		disk func(*DiskSet[int], *DiskSet[int]) (*DiskSet[int], error)
+		set  func(Set[int], Set[int]) Set[int]
+	}{
+		"Union":     {(*DiskSet[int]).Union, Set[int].Union},
+		"Intersect": {(*DiskSet[int]).Intersect, Set[int].Intersect},
+		"Minus":     {(*DiskSet[int]).Minus, Set[int].Minus},
+	}
+	for name, operation := range operations {
+		t.Run("DiskSet "+name+" matches the Set operation", func(t *testing.T) {
+			a, b := New(members[:600]...), New(members[400:]...)
+			diskA, diskB := newTestDiskSet(t, 50, a.AsList()...), newTestDiskSet(t, 70, b.AsList()...)
+			result, err := operation.disk(diskA, diskB)
+			expect(t, err == nil, "Unexpected error %v", err)
+			defer result.Close()
+			want := operation.set(a, b)
+			expect(t, result.Count() == want.Count(), "Expected Count %d, got %d", want.Count(), result.Count())
+			sorted := slices.Collect(result.Sorted())
+			expect(t, slices.Equal(sorted, want.AsSortedList()), "Expected %v, got %v", want.AsSortedList(), sorted)
+			for _, member := range want.AsList() {
+				found, _ := result.Contains(member)
+				expect(t, found, "Expected the result to contain %d", member)
+			}
+		})
+	}
+
+	t.Run("DiskSet works with other codecs and orderings", func(t *testing.T) {
+		descending := CompareFunc[string](func(a, b string) int { return -compareMembers(a, b) })
+		set := NewDiskSet(t.TempDir(), 3, StringCodec(), descending)
+		defer set.Close()
+		for i := 0; i < 10; i++ {
+			set.Add(strconv.Itoa(i))
+		}
+		sorted := slices.Collect(set.Sorted())
+		expect(t, slices.Equal(sorted, []string{"9", "8", "7", "6", "5", "4", "3", "2", "1", "0"}), "Expected descending order, got %v", sorted)
+		found, _ := set.Contains("7")
+		expect(t, found, "Expected DiskSet to contain 7")
+	})
+
+	t.Run("DiskSet Close removes its runs", func(t *testing.T) {
+		dir := t.TempDir()
+		set := NewDiskSet(dir, 2, JSONCodec[int](), nil)
+		set.Add(1, 2, 3, 4, 5)
+		err := set.Close()
+		expect(t, err == nil, "Unexpected error %v", err)
+		entries, _ := os.ReadDir(dir)
+		expect(t, len(entries) == 0, "Expected no files left, got %d", len(entries))
+		expect(t, set.Count() == 0, "Expected a closed DiskSet to be empty, got %d", set.Count())
+	})
+
+	t.Run("DiskSet reports errors reading a damaged run", func(t *testing.T) {
+		set := newTestDiskSet(t, 4, 1, 2, 3, 4)
+		set.runs[0].file.WriteAt([]byte{0x7f}, 0)
+		for range set.Sorted() {
+		}
+		expect(t, set.Err() != nil, "Expected an error reading the damaged run")
+		_, err := set.Union(newTestDiskSet(t, 4, 5))
+		expect(t, err != nil, "Expected Union to report the damaged run")
+	})
+
+	t.Run("DiskSet errors do not outlast the iteration that hit them", func(t *testing.T) {
+		set := newTestDiskSet(t, 4, 1, 2, 3, 4)
+		original := make([]byte, 1)
+		set.runs[0].file.ReadAt(original, 0)
+		set.runs[0].file.WriteAt([]byte{0x7f}, 0)
+		_, err := set.Union(newTestDiskSet(t, 4, 5))
+		expect(t, err != nil, "Expected Union to report the damaged run")
+
+		set.runs[0].file.WriteAt(original, 0)
+		union, err := set.Union(newTestDiskSet(t, 4, 5))
+		expect(t, err == nil, "Expected Union of the repaired run to succeed, got %v", err)
+		t.Cleanup(func() { union.Close() })
+		expect(t, union.Count() == 5, "Expected 5 members, got %d", union.Count())
+		for range set.Sorted() {
+		}
+		expect(t, set.Err() == nil, "Expected Sorted to succeed, got %v", set.Err())
+	})
+
+	t.Run("NewDiskSet panics with no room in memory", func(t *testing.T) {
+		defer func() {
+			expect(t, recover() != nil, "Expected NewDiskSet to panic")
+		}()
+		NewDiskSet(t.TempDir(), 0, JSONCodec[int](), nil)
+	})
+}
+
+func BenchmarkDiskSet_Sorted(b *testing.B) {
+	for _, runs := range []int{20, 200, 1000} {
+		b.Run(strconv.Itoa(runs)+" runs", func(b *testing.B) {
+			set := NewDiskSet(b.TempDir(), 20000/runs, JSONCodec[int](), nil)
+			defer set.Close()
+			set.Add(rand.New(rand.NewSource(1)).Perm(20000)...)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for range set.Sorted() {
+				}
+			}
+		})
+	}
+}
+
+func TestBloomFilter(t *testing.T) {
+	filter := newBloomFilter(1000, 0.01)
+	for member := 0; member < 1000; member++ {
+		filter.add(member)
+	}
+	falsePositives := 0
+	for member := 0; member < 2000; member++ {
+		if member < 1000 {
+			expect(t, filter.mayContain(member), "Expected no false negative for %d", member)
+		} else if filter.mayContain(member) {
+			falsePositives++
+		}
+	}
+	expect(t, falsePositives < 50, "Expected around 1%% false positives, got %d in 1000", falsePositives)
+}
@@ -0,0 +1,341 @@
+// Package expr parses and evaluates set expressions such as
+//
+//	(admins | editors) - suspended & active
+//
+// against named goset.Sets.
+//
+// The grammar supports four left-associative binary operators: & (intersect) binds tightest,
+// followed by | (union), - (minus) and ^ (symmetric difference), which share a precedence as in Go.
+// Parentheses group sub-expressions. Set names are identifiers made of letters, digits, '_' and
+// '.', not starting with a digit, or any text in double quotes, with \" and \\ escapes.
+package expr
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/daynemay/goset"
+)
+
+// Error reports a problem with an expression, and the byte offset within it where the problem was found
+type Error struct {
+	Offset int
+	Msg    string
+}
+
+// Error returns a description of theError, with its 1-based column
+func (theError *Error) Error() string {
+	return fmt.Sprintf("expr: column %d: %s", theError.Offset+1, theError.Msg)
+}
+
+// Expr is a parsed set expression, as returned by Parse
+type Expr interface {
+	// String returns a fully parenthesized representation of the expression
+	String() string
+	// Names returns the set names used in the expression, each once, in order of first use
+	Names() []string
+}
+
+// Op is a binary set operator
+type Op byte
+
+// The operators, written as they appear in expressions
+const (
+	Union               Op = '|'
+	Intersect           Op = '&'
+	Minus               Op = '-'
+	SymmetricDifference Op = '^'
+)
+
+// Name is an Expr referring to a named set
+type Name struct {
+	Name   string
+	Offset int
+}
+
+// Binary is an Expr combining two sub-expressions with an Op
+type Binary struct {
+	Op          Op
+	Left, Right Expr
+	Offset      int
+}
+
+func (name *Name) String() string {
+	if isIdentifier(name.Name) {
+		return name.Name
+	}
+	return fmt.Sprintf("%q", name.Name)
+}
+
+func (name *Name) Names() []string {
+	return []string{name.Name}
+}
+
+func (binary *Binary) String() string {
+	return fmt.Sprintf("(%s %c %s)", binary.Left, binary.Op, binary.Right)
+}
+
+func (binary *Binary) Names() []string {
+	names := binary.Left.Names()
+	seen := goset.New(names...)
+	for _, name := range binary.Right.Names() {
+		if !seen.Contains(name) {
+			seen.Add(name)
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Parse parses input as a set expression
+func Parse(input string) (Expr, error) {
+	p := &parser{input: input}
+	p.next()
+	e, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	if p.token.kind != tokenEOF {
+		return nil, p.errorf("unexpected %s", p.token)
+	}
+	return e, nil
+}
+
+// Eval evaluates e against the named sets, using Union, Intersect and Minus.
+// The result is a new Set; none of sets are modified. It returns an *Error locating the first
+// name in e that is missing from sets.
+func Eval[T comparable](e Expr, sets map[string]goset.Set[T]) (goset.Set[T], error) {
+	switch node := e.(type) {
+	case *Name:
+		set, ok := sets[node.Name]
+		if !ok {
+			return goset.Set[T]{}, &Error{Offset: node.Offset, Msg: fmt.Sprintf("unknown set %q", node.Name)}
+		}
+		return set.Clone(), nil
+	case *Binary:
+		left, err := Eval(node.Left, sets)
+		if err != nil {
+			return goset.Set[T]{}, err
+		}
+		right, err := Eval(node.Right, sets)
+		if err != nil {
+			return goset.Set[T]{}, err
+		}
+		switch node.Op {
+		case Union:
+			return left.Union(right), nil
+		case Intersect:
+			return left.Intersect(right), nil
+		case Minus:
+			return left.Minus(right), nil
+		case SymmetricDifference:
+			return left.Minus(right).Union(right.Minus(left)), nil
+		}
+		return goset.Set[T]{}, &Error{Offset: node.Offset, Msg: fmt.Sprintf("unknown operator %q", node.Op)}
+	default:
+		return goset.Set[T]{}, fmt.Errorf("expr: unknown expression type %T", e)
+	}
+}
+
+// Evaluate parses input and evaluates it against the named sets
+func Evaluate[T comparable](input string, sets map[string]goset.Set[T]) (goset.Set[T], error) {
+	e, err := Parse(input)
+	if err != nil {
+		return goset.Set[T]{}, err
+	}
+	return Eval(e, sets)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenName
+	tokenOp
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of expression"
+	case tokenName:
+		return fmt.Sprintf("name %q", t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// parser is a recursive descent parser holding one token of lookahead.
+type parser struct {
+	input  string
+	offset int
+	token  token
+	err    *Error
+}
+
+func (p *parser) errorf(format string, args ...interface{}) *Error {
+	return &Error{Offset: p.token.offset, Msg: fmt.Sprintf(format, args...)}
+}
+
+// parseExpr parses a sequence of terms joined by the lower precedence operators.
+func (p *parser) parseExpr() (Expr, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.token.kind == tokenOp && Op(p.token.text[0]) != Intersect {
+		op := p.token
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: Op(op.text[0]), Left: left, Right: right, Offset: op.offset}
+	}
+	return left, nil
+}
+
+// parseTerm parses a sequence of factors joined by &.
+func (p *parser) parseTerm() (Expr, error) {
+	left, err := p.parseFactor()
+	if err != nil {
+		return nil, err
+	}
+	for p.token.kind == tokenOp && Op(p.token.text[0]) == Intersect {
+		op := p.token
+		p.next()
+		right, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: Intersect, Left: left, Right: right, Offset: op.offset}
+	}
+	return left, nil
+}
+
+// parseFactor parses a name or a parenthesized expression.
+func (p *parser) parseFactor() (Expr, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	switch p.token.kind {
+	case tokenName:
+		name := &Name{Name: p.token.text, Offset: p.token.offset}
+		p.next()
+		return name, nil
+	case tokenLeftParen:
+		open := p.token
+		p.next()
+		e, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.err != nil {
+			return nil, p.err
+		}
+		if p.token.kind != tokenRightParen {
+			return nil, p.errorf("expected ')' to close '(' at column %d, found %s", open.offset+1, p.token)
+		}
+		p.next()
+		return e, nil
+	default:
+		return nil, p.errorf("expected a set name or '(', found %s", p.token)
+	}
+}
+
+// next advances to the next token, recording the first lexical error in p.err.
+func (p *parser) next() {
+	for p.offset < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.offset:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		p.offset += size
+	}
+	start := p.offset
+	if p.offset >= len(p.input) {
+		p.token = token{kind: tokenEOF, offset: start}
+		return
+	}
+
+	r, size := utf8.DecodeRuneInString(p.input[p.offset:])
+	switch {
+	case strings.ContainsRune("|&-^", r):
+		p.offset += size
+		p.token = token{kind: tokenOp, text: string(r), offset: start}
+	case r == '(':
+		p.offset += size
+		p.token = token{kind: tokenLeftParen, text: "(", offset: start}
+	case r == ')':
+		p.offset += size
+		p.token = token{kind: tokenRightParen, text: ")", offset: start}
+	case r == '"':
+		p.lexQuoted()
+	case isIdentifierStart(r):
+		for p.offset < len(p.input) {
+			r, size := utf8.DecodeRuneInString(p.input[p.offset:])
+			if !isIdentifierPart(r) {
+				break
+			}
+			p.offset += size
+		}
+		p.token = token{kind: tokenName, text: p.input[start:p.offset], offset: start}
+	default:
+		p.token = token{kind: tokenEOF, offset: start}
+		p.err = &Error{Offset: start, Msg: fmt.Sprintf("unexpected character %q", r)}
+	}
+}
+
+// lexQuoted lexes a double quoted name, starting at the opening quote.
+func (p *parser) lexQuoted() {
+	start := p.offset
+	p.offset++
+	var sb strings.Builder
+	for p.offset < len(p.input) {
+		c := p.input[p.offset]
+		switch {
+		case c == '"':
+			p.offset++
+			p.token = token{kind: tokenName, text: sb.String(), offset: start}
+			return
+		case c == '\\' && p.offset+1 < len(p.input) && strings.ContainsRune(`"\`, rune(p.input[p.offset+1])):
+			sb.WriteByte(p.input[p.offset+1])
+			p.offset += 2
+		default:
+			sb.WriteByte(c)
+			p.offset++
+		}
+	}
+	p.token = token{kind: tokenEOF, offset: start}
+	p.err = &Error{Offset: start, Msg: "unterminated quoted name"}
+}
+
+func isIdentifierStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isIdentifierPart(r rune) bool {
+	return r == '_' || r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isIdentifier(s string) bool {
+	for idx, r := range s {
+		if !isIdentifierPart(r) || (idx == 0 && !isIdentifierStart(r)) {
+			return false
+		}
+	}
+	return s != ""
+}
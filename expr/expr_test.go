@@ -0,0 +1,104 @@
+package expr
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/daynemay/goset"
+)
+
+func expect(t *testing.T, condition bool, description string, subs ...interface{}) {
+	if !condition {
+		t.Errorf(description, subs...)
+	}
+}
+
+var users = map[string]goset.Set[string]{
+	"admins":    goset.New("alice", "bob"),
+	"editors":   goset.New("carol", "dave", "bob"),
+	"suspended": goset.New("bob", "dave", "erin"),
+	"active":    goset.New("alice", "bob", "carol", "erin"),
+	"ops team":  goset.New("frank"),
+}
+
+func TestParse(t *testing.T) {
+	t.Run("& binds tighter than |, - and ^, which are left-associative", func(t *testing.T) {
+		cases := map[string]string{
+			"(admins | editors) - suspended & active": "((admins | editors) - (suspended & active))",
+			"a | b - c ^ d":      "(((a | b) - c) ^ d)",
+			"a & b | c & d":      "((a & b) | (c & d))",
+			"a - (b - c)":        "(a - (b - c))",
+			`"ops team" | x.y_2`: `("ops team" | x.y_2)`,
+			`"say \"hi\""`:       `"say \"hi\""`,
+		}
+		for input, expected := range cases {
+			e, err := Parse(input)
+			expect(t, err == nil, "Parse(%q) returned error %v", input, err)
+			if err == nil {
+				expect(t, e.String() == expected, "Parse(%q) = %s, expected %s", input, e, expected)
+			}
+		}
+	})
+
+	t.Run("Names lists each set name once, in order of first use", func(t *testing.T) {
+		e, err := Parse("b | a & b - c")
+		expect(t, err == nil, "Unexpected error %v", err)
+		expected := []string{"b", "a", "c"}
+		expect(t, reflect.DeepEqual(e.Names(), expected), "Expected %v, got %v", expected, e.Names())
+	})
+
+	t.Run("Parse errors report their position", func(t *testing.T) {
+		cases := map[string]int{
+			"":                  0,
+			"admins |":          8,
+			"(admins | editors": 17,
+			"admins editors":    7,
+			"admins | $":        9,
+			"admins $":          7,
+			`admins | "ops`:     9,
+			"admins | )":        9,
+		}
+		for input, offset := range cases {
+			_, err := Parse(input)
+			var exprErr *Error
+			expect(t, errors.As(err, &exprErr), "Parse(%q) should return an *Error, got %v", input, err)
+			if exprErr != nil {
+				expect(t, exprErr.Offset == offset, "Parse(%q) error at offset %v, expected %v (%v)", input, exprErr.Offset, offset, err)
+			}
+		}
+	})
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Run("Evaluate applies each operator", func(t *testing.T) {
+		cases := map[string]goset.Set[string]{
+			"(admins | editors) - suspended & active": goset.New("alice", "carol", "dave"),
+			"(admins | editors) - suspended":          goset.New("alice", "carol"),
+			"admins & editors":                        goset.New("bob"),
+			"admins ^ editors":                        goset.New("alice", "carol", "dave"),
+			`admins | "ops team"`:                     goset.New("alice", "bob", "frank"),
+		}
+		for input, expected := range cases {
+			actual, err := Evaluate(input, users)
+			expect(t, err == nil, "Evaluate(%q) returned error %v", input, err)
+			expect(t, actual.Equals(expected), "Evaluate(%q) = %s, expected %s", input, actual, expected)
+		}
+	})
+
+	t.Run("Evaluate does not modify the named sets", func(t *testing.T) {
+		sets := map[string]goset.Set[int]{"a": goset.New(1, 2), "b": goset.New(3)}
+		result, err := Evaluate("a", sets)
+		expect(t, err == nil, "Unexpected error %v", err)
+		result.Add(4)
+		expect(t, sets["a"].Equals(goset.New(1, 2)), "Expected named set to be unchanged, got %s", sets["a"])
+	})
+
+	t.Run("Evaluate reports the position of unknown names", func(t *testing.T) {
+		_, err := Evaluate("admins | moderators", users)
+		var exprErr *Error
+		expect(t, errors.As(err, &exprErr), "Expected an *Error, got %v", err)
+		expect(t, exprErr != nil && exprErr.Offset == 9, "Expected offset 9, got %v", err)
+		expect(t, err.Error() == `expr: column 10: unknown set "moderators"`, "Unexpected message %q", err)
+	})
+}
@@ -0,0 +1,515 @@
+package goset
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrCorruptFile is returned when a FileSet snapshot fails its checksum or is otherwise unreadable
+var ErrCorruptFile = errors.New("goset: corrupt file")
+
+// SyncPolicy controls when a FileSet forces its write-ahead log to stable storage
+type SyncPolicy int
+
+const (
+	// SyncAlways syncs the log before each Add or Remove returns, so that no acknowledged change is lost
+	SyncAlways SyncPolicy = iota
+	// SyncPeriodic syncs the log on an Add or Remove when SyncInterval has passed since the last sync.
+	// The interval is only checked on the next Add or Remove, so changes made before a quiet period
+	// stay unsynced until then, or until Sync or Close.
+	SyncPeriodic
+	// SyncNever leaves syncing the log to the operating system, and to explicit calls to Sync
+	SyncNever
+)
+
+// FileSetOptions configure a FileSet
+type FileSetOptions struct {
+	// Sync chooses when the log is synced. The zero value is SyncAlways.
+	Sync SyncPolicy
+	// SyncInterval is the interval used by SyncPeriodic, defaulting to one second
+	SyncInterval time.Duration
+	// CompactAfter, if positive, compacts the FileSet whenever its log holds that many records
+	CompactAfter int
+}
+
+const (
+	snapshotMagic = "GOSETSNP"
+	walMagic      = "GOSETWAL"
+	fileVersion   = 1
+)
+
+const (
+	walAdd    byte = 1
+	walRemove byte = 2
+)
+
+// FileSet is a durable set stored in the file at path. Each Add and Remove is appended to a
+// write-ahead log at path+".wal" before it is applied in memory, and Compact rewrites the
+// members as a sorted snapshot at path, emptying the log. On opening, the snapshot is
+// loaded and the log replayed. A record at the end of the log that a crash cut short or left
+// failing its checksum is truncated away. Any other damage to the log, or an intact record
+// that the codec cannot decode, fails the open with ErrCorruptFile and leaves the log as it is.
+// It is safe for concurrent use.
+type FileSet[T comparable] struct {
+	mu         sync.Mutex
+	path       string
+	codec      Codec[T]
+	options    FileSetOptions
+	set        Set[T]
+	wal        *os.File
+	walRecords int
+	lastSync   time.Time
+	now        func() time.Time
+	// crash, if set, is called at each step of a write that a crash could interrupt, and stops the
+	// write there if it returns an error. It lets tests inject crashes.
+	crash func(point string) error
+}
+
+// OpenFileSet opens the FileSet stored at path, creating it if it does not exist, and recovers its
+// members from the snapshot and log
+func OpenFileSet[T comparable](path string, codec Codec[T], options FileSetOptions) (*FileSet[T], error) {
+	if options.SyncInterval <= 0 {
+		options.SyncInterval = time.Second
+	}
+	theSet := &FileSet[T]{
+		path:    path,
+		codec:   codec,
+		options: options,
+		set:     New[T](),
+		now:     time.Now,
+	}
+	if err := theSet.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	if err := theSet.openWAL(); err != nil {
+		return nil, err
+	}
+	theSet.lastSync = theSet.now()
+	return theSet, nil
+}
+
+// Add adds members to theSet, logging those not already present
+func (theSet *FileSet[T]) Add(members ...T) error {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	return theSet.apply(walAdd, members)
+}
+
+// Remove removes members from theSet, logging those that were present
+func (theSet *FileSet[T]) Remove(members ...T) error {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	return theSet.apply(walRemove, members)
+}
+
+// Contains returns a boolean indicating whether theSet contains all the given values
+func (theSet *FileSet[T]) Contains(values ...T) bool {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	return theSet.set.Contains(values...)
+}
+
+// Count returns the number of members in theSet
+func (theSet *FileSet[T]) Count() int {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	return theSet.set.Count()
+}
+
+// AsList returns a slice of values in theSet
+func (theSet *FileSet[T]) AsList() []T {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	return theSet.set.AsList()
+}
+
+// Snapshot returns the members of theSet as a new Set
+func (theSet *FileSet[T]) Snapshot() Set[T] {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	return theSet.set.Clone()
+}
+
+// Sync forces the log of theSet to stable storage
+func (theSet *FileSet[T]) Sync() error {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	return theSet.sync()
+}
+
+// Compact writes the members of theSet to a new sorted snapshot, replacing the old one, and empties the log
+func (theSet *FileSet[T]) Compact() error {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	return theSet.compact()
+}
+
+// Close syncs and closes the log of theSet, after which it must not be used
+func (theSet *FileSet[T]) Close() error {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	return errors.Join(theSet.sync(), theSet.wal.Close())
+}
+
+// apply logs and applies the members that op would change, then syncs and compacts according to the options.
+func (theSet *FileSet[T]) apply(op byte, members []T) error {
+	var records bytes.Buffer
+	changed := make([]T, 0, len(members))
+	for _, member := range members {
+		if (op == walAdd) == theSet.set.Contains(member) {
+			continue
+		}
+		data, err := theSet.codec.Encode(member)
+		if err != nil {
+			return err
+		}
+		appendWALRecord(&records, op, data)
+		changed = append(changed, member)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	offset, err := theSet.wal.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := theSet.wal.Write(records.Bytes()); err != nil {
+		// Cut off any partial write, which would otherwise end the log on recovery.
+		return errors.Join(err, theSet.wal.Truncate(offset), seekTo(theSet.wal, offset))
+	}
+	if err := theSet.crashPoint("wal-written"); err != nil {
+		return err
+	}
+	if op == walAdd {
+		theSet.set.Add(changed...)
+	} else {
+		theSet.set.Remove(changed...)
+	}
+	theSet.walRecords += len(changed)
+
+	switch theSet.options.Sync {
+	case SyncAlways:
+		if err := theSet.sync(); err != nil {
+			return err
+		}
+	case SyncPeriodic:
+		if theSet.now().Sub(theSet.lastSync) >= theSet.options.SyncInterval {
+			if err := theSet.sync(); err != nil {
+				return err
+			}
+		}
+	}
+	if theSet.options.CompactAfter > 0 && theSet.walRecords >= theSet.options.CompactAfter {
+		return theSet.compact()
+	}
+	return nil
+}
+
+// sync syncs the log and notes the time.
+func (theSet *FileSet[T]) sync() error {
+	if err := theSet.wal.Sync(); err != nil {
+		return err
+	}
+	theSet.lastSync = theSet.now()
+	return nil
+}
+
+// compact writes a snapshot to a temporary file, renames it over the old one and then empties the log.
+// A crash before the rename leaves the old snapshot and full log; a crash after it leaves the new snapshot
+// and a log whose replay changes nothing further.
+func (theSet *FileSet[T]) compact() error {
+	temporary := theSet.path + ".tmp"
+	if err := theSet.writeSnapshot(temporary); err != nil {
+		return err
+	}
+	if err := theSet.crashPoint("snapshot-written"); err != nil {
+		return err
+	}
+	if err := os.Rename(temporary, theSet.path); err != nil {
+		return err
+	}
+	if err := syncDir(filepath.Dir(theSet.path)); err != nil {
+		return err
+	}
+	if err := theSet.crashPoint("snapshot-renamed"); err != nil {
+		return err
+	}
+	headerSize := int64(len(walMagic) + 1)
+	if err := theSet.wal.Truncate(headerSize); err != nil {
+		return err
+	}
+	if err := seekTo(theSet.wal, headerSize); err != nil {
+		return err
+	}
+	theSet.walRecords = 0
+	return theSet.sync()
+}
+
+// writeSnapshot writes the members of theSet in sorted order to a synced file at path, with a header
+// and a trailing checksum.
+func (theSet *FileSet[T]) writeSnapshot(path string) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+
+	checksum := crc32.NewIEEE()
+	w := bufio.NewWriter(io.MultiWriter(file, checksum))
+	w.WriteString(snapshotMagic)
+	w.WriteByte(fileVersion)
+	var count [binary.MaxVarintLen64]byte
+	w.Write(count[:binary.PutUvarint(count[:], uint64(theSet.set.Count()))])
+	for _, member := range theSet.set.AsSortedList() {
+		data, err := theSet.codec.Encode(member)
+		if err != nil {
+			return err
+		}
+		if _, err := writeRecord(w, data); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := binary.Write(file, binary.LittleEndian, checksum.Sum32()); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// loadSnapshot adds the members of the snapshot at theSet.path, if there is one.
+func (theSet *FileSet[T]) loadSnapshot() error {
+	contents, err := os.ReadFile(theSet.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	corrupt := func(reason string) error {
+		return fmt.Errorf("%w: %s: %s", ErrCorruptFile, theSet.path, reason)
+	}
+	if len(contents) < len(snapshotMagic)+1+4 || string(contents[:len(snapshotMagic)]) != snapshotMagic {
+		return corrupt("not a snapshot")
+	}
+	if version := contents[len(snapshotMagic)]; version != fileVersion {
+		return corrupt(fmt.Sprintf("unsupported version %d", version))
+	}
+	body, trailer := contents[:len(contents)-4], contents[len(contents)-4:]
+	if crc32.ChecksumIEEE(body) != binary.LittleEndian.Uint32(trailer) {
+		return corrupt("checksum mismatch")
+	}
+
+	r := bufio.NewReader(bytes.NewReader(body[len(snapshotMagic)+1:]))
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return corrupt(err.Error())
+	}
+	theSet.set = theSet.set.Grow(int(count))
+	for i := uint64(0); i < count; i++ {
+		data, err := readRecord(r)
+		if err != nil {
+			return corrupt(err.Error())
+		}
+		member, err := theSet.codec.Decode(data)
+		if err != nil {
+			return corrupt(err.Error())
+		}
+		theSet.set.Add(member)
+	}
+	return nil
+}
+
+// openWAL opens the log, replays its intact records and truncates a torn record at its end.
+func (theSet *FileSet[T]) openWAL() (err error) {
+	wal, err := os.OpenFile(theSet.path+".wal", os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			wal.Close()
+		}
+	}()
+	corrupt := func(reason string) error {
+		return fmt.Errorf("%w: %s: %s", ErrCorruptFile, wal.Name(), reason)
+	}
+
+	header := append([]byte(walMagic), fileVersion)
+	r := bufio.NewReader(wal)
+	existing := make([]byte, len(header))
+	n, err := io.ReadFull(r, existing)
+	switch {
+	case err == nil:
+		if !bytes.Equal(existing, header) {
+			return corrupt("not a write-ahead log")
+		}
+	case err != io.EOF && err != io.ErrUnexpectedEOF:
+		return err
+	case !bytes.Equal(existing[:n], header[:n]):
+		return corrupt("not a write-ahead log")
+	default:
+		// A missing or torn header means the log was never written to.
+		if err := wal.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := wal.WriteAt(header, 0); err != nil {
+			return err
+		}
+		if err := seekTo(wal, int64(len(header))); err != nil {
+			return err
+		}
+		r.Reset(wal)
+	}
+
+	info, err := wal.Stat()
+	if err != nil {
+		return err
+	}
+	end := int64(len(header))
+	for {
+		op, data, size, err := readWALRecord(r, info.Size()-end)
+		if err == io.EOF || err == errTornRecord {
+			break
+		}
+		if errors.Is(err, errBadRecord) {
+			return corrupt(fmt.Sprintf("%s at offset %d", err, end))
+		}
+		if err != nil {
+			return err
+		}
+		member, err := theSet.codec.Decode(data)
+		if err != nil {
+			// The record is intact, so it was written with another codec: stop rather than truncate it.
+			return corrupt(err.Error())
+		}
+		if op == walAdd {
+			theSet.set.Add(member)
+		} else {
+			theSet.set.Remove(member)
+		}
+		theSet.walRecords++
+		end += size
+	}
+	if err := wal.Truncate(end); err != nil {
+		return err
+	}
+	if err := seekTo(wal, end); err != nil {
+		return err
+	}
+	if err := wal.Sync(); err != nil {
+		return err
+	}
+	theSet.wal = wal
+	return nil
+}
+
+// crashPoint calls theSet.crash, if set.
+func (theSet *FileSet[T]) crashPoint(point string) error {
+	if theSet.crash == nil {
+		return nil
+	}
+	return theSet.crash(point)
+}
+
+// appendWALRecord appends a log record of op on the encoded member data to buf: the op, the length
+// of data, data itself and a checksum of all three.
+func appendWALRecord(buf *bytes.Buffer, op byte, data []byte) {
+	start := buf.Len()
+	buf.WriteByte(op)
+	writeRecord(buf, data)
+	var checksum [4]byte
+	binary.LittleEndian.PutUint32(checksum[:], crc32.ChecksumIEEE(buf.Bytes()[start:]))
+	buf.Write(checksum[:])
+}
+
+// errTornRecord reports a log record cut short or left damaged at the end of the log, as a crash
+// while appending it can leave it.
+var errTornRecord = errors.New("torn record")
+
+// errBadRecord reports a damaged log record that a crash cannot explain, because it is not at the
+// end of the log.
+var errBadRecord = errors.New("damaged record")
+
+// readWALRecord reads a record written by appendWALRecord from the remaining bytes of the log, returning
+// its size. It returns io.EOF at the end of the log, errTornRecord if the record runs to the end of the
+// log but is incomplete or fails its checksum, and an error wrapping errBadRecord if it is damaged
+// with more of the log after it.
+func readWALRecord(r *bufio.Reader, remaining int64) (op byte, data []byte, size int64, err error) {
+	torn := func(err error) error {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return errTornRecord
+		}
+		return err
+	}
+	op, err = r.ReadByte()
+	if err != nil {
+		return 0, nil, 0, err
+	}
+	if op != walAdd && op != walRemove {
+		return 0, nil, 0, fmt.Errorf("%w: unknown operation %d", errBadRecord, op)
+	}
+	var prefix [1 + binary.MaxVarintLen64]byte
+	prefix[0] = op
+	n := 1
+	for n < len(prefix) {
+		if prefix[n], err = r.ReadByte(); err != nil {
+			return 0, nil, 0, torn(err)
+		}
+		n++
+		if prefix[n-1] < 0x80 {
+			break
+		}
+	}
+	length, lengthSize := binary.Uvarint(prefix[1:n])
+	if lengthSize <= 0 {
+		return 0, nil, 0, fmt.Errorf("%w: bad length", errBadRecord)
+	}
+	if length > uint64(remaining) || int64(n)+int64(length)+4 > remaining {
+		return 0, nil, 0, errTornRecord
+	}
+	record := make([]byte, n+int(length))
+	copy(record, prefix[:n])
+	if _, err := io.ReadFull(r, record[n:]); err != nil {
+		return 0, nil, 0, torn(err)
+	}
+	var checksum [4]byte
+	if _, err := io.ReadFull(r, checksum[:]); err != nil {
+		return 0, nil, 0, torn(err)
+	}
+	size = int64(len(record) + 4)
+	if crc32.ChecksumIEEE(record) != binary.LittleEndian.Uint32(checksum[:]) {
+		if size == remaining {
+			return 0, nil, 0, errTornRecord
+		}
+		return 0, nil, 0, fmt.Errorf("%w: checksum mismatch", errBadRecord)
+	}
+	return op, record[n:], size, nil
+}
+
+// seekTo sets the offset of file for the next Write.
+func seekTo(file *os.File, offset int64) error {
+	_, err := file.Seek(offset, io.SeekStart)
+	return err
+}
+
+// syncDir syncs the directory dir, so that a rename within it is durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	return errors.Join(d.Sync(), d.Close())
+}
@@ -0,0 +1,243 @@
+package goset
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// errCrash stands in for the process dying at a crash point.
+var errCrash = errors.New("crash")
+
+// openTestFileSet opens the FileSet of strings at path, failing the test on error.
+func openTestFileSet(t *testing.T, path string, options FileSetOptions) *FileSet[string] {
+	t.Helper()
+	set, err := OpenFileSet(path, StringCodec(), options)
+	if err != nil {
+		t.Fatalf("Unexpected error opening %s: %v", path, err)
+	}
+	return set
+}
+
+// copyFiles copies the FileSet at path, truncating its log to walSize bytes, and returns the path of the copy.
+func copyFiles(t *testing.T, path string, walSize int64) string {
+	t.Helper()
+	copied := filepath.Join(t.TempDir(), "set")
+	if contents, err := os.ReadFile(path); err == nil {
+		os.WriteFile(copied, contents, 0o644)
+	}
+	wal, _ := os.ReadFile(path + ".wal")
+	os.WriteFile(copied+".wal", wal[:walSize], 0o644)
+	return copied
+}
+
+func TestFileSet(t *testing.T) {
+	t.Run("FileSet recovers its members from the log", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "set")
+		set := openTestFileSet(t, path, FileSetOptions{})
+		set.Add("a", "b", "c")
+		set.Remove("b", "z")
+		expect(t, set.Close() == nil, "Unexpected error closing")
+
+		reopened := openTestFileSet(t, path, FileSetOptions{})
+		defer reopened.Close()
+		expect(t, reopened.Snapshot().Equals(New("a", "c")), "Expected {a, c}, got %s", reopened.Snapshot())
+	})
+
+	t.Run("FileSet Compact writes a snapshot and empties the log", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "set")
+		set := openTestFileSet(t, path, FileSetOptions{})
+		set.Add("b", "a", "c")
+		expect(t, set.Compact() == nil, "Unexpected error compacting")
+		set.Remove("a")
+		set.Close()
+
+		wal, _ := os.Stat(path + ".wal")
+		expect(t, wal.Size() < 32, "Expected a short log after compaction, got %d bytes", wal.Size())
+		reopened := openTestFileSet(t, path, FileSetOptions{})
+		defer reopened.Close()
+		expect(t, reopened.Snapshot().Equals(New("b", "c")), "Expected {b, c}, got %s", reopened.Snapshot())
+	})
+
+	t.Run("FileSet compacts automatically after CompactAfter records", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "set")
+		set := openTestFileSet(t, path, FileSetOptions{CompactAfter: 3})
+		defer set.Close()
+		set.Add("a", "b")
+		_, err := os.Stat(path)
+		expect(t, errors.Is(err, os.ErrNotExist), "Expected no snapshot yet, got %v", err)
+		set.Add("c")
+		_, err = os.Stat(path)
+		expect(t, err == nil, "Expected a snapshot, got %v", err)
+	})
+
+	t.Run("FileSet survives a crash at any point in the log", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "set")
+		set := openTestFileSet(t, path, FileSetOptions{})
+		operations := []struct {
+			op     func(...string) error
+			member string
+		}{
+			{set.Add, "alpha"}, {set.Add, "beta"}, {set.Remove, "alpha"}, {set.Add, "gamma"}, {set.Add, "alpha"},
+		}
+		expected := New[string]()
+		states := map[int64]Set[string]{}
+		info, _ := set.wal.Stat()
+		states[info.Size()] = expected.Clone()
+		for _, operation := range operations {
+			operation.op(operation.member)
+			if set.Contains(operation.member) {
+				expected.Add(operation.member)
+			} else {
+				expected.Remove(operation.member)
+			}
+			info, _ = set.wal.Stat()
+			states[info.Size()] = expected.Clone()
+		}
+		set.Close()
+
+		recovered := New[string]()
+		for size := int64(0); size <= info.Size(); size++ {
+			if state, ok := states[size]; ok {
+				recovered = state
+			}
+			copied := copyFiles(t, path, size)
+			reopened := openTestFileSet(t, copied, FileSetOptions{})
+			expect(t, reopened.Snapshot().Equals(recovered),
+				"Expected %s after a crash with %d bytes of log, got %s", recovered, size, reopened.Snapshot())
+			reopened.Add("delta")
+			reopened.Close()
+			again := openTestFileSet(t, copied, FileSetOptions{})
+			expect(t, again.Contains("delta"), "Expected a write after recovery to survive, with %d bytes of log", size)
+			again.Close()
+		}
+	})
+
+	t.Run("FileSet drops a damaged record at the end of the log", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "set")
+		set := openTestFileSet(t, path, FileSetOptions{})
+		set.Add("a")
+		set.Add("b")
+		set.Close()
+		wal, _ := os.ReadFile(path + ".wal")
+		wal[len(wal)-5] ^= 0xff
+		os.WriteFile(path+".wal", wal, 0o644)
+
+		reopened := openTestFileSet(t, path, FileSetOptions{})
+		defer reopened.Close()
+		expect(t, reopened.Snapshot().Equals(New("a")), "Expected {a}, got %s", reopened.Snapshot())
+	})
+
+	t.Run("OpenFileSet rejects a damaged record before the end of the log, leaving it intact", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "set")
+		set := openTestFileSet(t, path, FileSetOptions{})
+		for _, member := range []string{"a", "b", "c", "d"} {
+			set.Add(member)
+		}
+		set.Close()
+		wal, _ := os.ReadFile(path + ".wal")
+		wal[len(walMagic)+1+2] ^= 0xff
+		os.WriteFile(path+".wal", wal, 0o644)
+
+		_, err := OpenFileSet(path, StringCodec(), FileSetOptions{})
+		expect(t, errors.Is(err, ErrCorruptFile), "Expected ErrCorruptFile, got %v", err)
+		after, _ := os.ReadFile(path + ".wal")
+		expect(t, bytes.Equal(wal, after), "Expected the log to be left intact, got %d bytes of %d", len(after), len(wal))
+	})
+
+	for _, point := range []string{"wal-written", "snapshot-written", "snapshot-renamed"} {
+		t.Run("FileSet survives a crash at "+point, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "set")
+			set := openTestFileSet(t, path, FileSetOptions{})
+			set.Add("a", "b")
+			set.Compact()
+			set.Remove("a")
+			set.Add("c")
+			set.crash = func(at string) error {
+				if at == point {
+					return errCrash
+				}
+				return nil
+			}
+			var err error
+			if point == "wal-written" {
+				err = set.Add("d")
+			} else {
+				err = set.Compact()
+			}
+			expect(t, errors.Is(err, errCrash), "Expected the crash to be injected, got %v", err)
+			set.wal.Close()
+
+			reopened := openTestFileSet(t, path, FileSetOptions{})
+			defer reopened.Close()
+			want := New("b", "c")
+			if point == "wal-written" {
+				want.Add("d")
+			}
+			expect(t, reopened.Snapshot().Equals(want), "Expected %s, got %s", want, reopened.Snapshot())
+		})
+	}
+
+	t.Run("FileSet syncs according to its policy", func(t *testing.T) {
+		now := time.Unix(0, 0)
+		syncs := func(policy SyncPolicy, advance time.Duration) int {
+			set := openTestFileSet(t, filepath.Join(t.TempDir(), "set"), FileSetOptions{Sync: policy, SyncInterval: time.Minute})
+			defer set.wal.Close()
+			set.now = func() time.Time { return now }
+			set.lastSync = now
+			count := 0
+			for _, member := range []string{"a", "b", "c", "d"} {
+				now = now.Add(advance)
+				before := set.lastSync
+				set.Add(member)
+				if set.lastSync != before {
+					count++
+				}
+			}
+			return count
+		}
+		expect(t, syncs(SyncAlways, time.Second) == 4, "Expected SyncAlways to sync every Add")
+		expect(t, syncs(SyncNever, time.Hour) == 0, "Expected SyncNever never to sync")
+		expect(t, syncs(SyncPeriodic, 30*time.Second) == 2, "Expected SyncPeriodic to sync every other Add")
+	})
+
+	t.Run("OpenFileSet rejects a damaged snapshot", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "set")
+		set := openTestFileSet(t, path, FileSetOptions{})
+		set.Add("a", "b")
+		set.Compact()
+		set.Close()
+		snapshot, _ := os.ReadFile(path)
+		snapshot[len(snapshotMagic)+3] ^= 0xff
+		os.WriteFile(path, snapshot, 0o644)
+
+		_, err := OpenFileSet(path, StringCodec(), FileSetOptions{})
+		expect(t, errors.Is(err, ErrCorruptFile), "Expected ErrCorruptFile, got %v", err)
+	})
+
+	t.Run("OpenFileSet rejects a file that is not a log", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "set")
+		os.WriteFile(path+".wal", []byte("hello, world"), 0o644)
+		_, err := OpenFileSet(path, StringCodec(), FileSetOptions{})
+		expect(t, errors.Is(err, ErrCorruptFile), "Expected ErrCorruptFile, got %v", err)
+	})
+	t.Run("OpenFileSet rejects a log written with another codec, leaving it intact", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "set")
+		set := openTestFileSet(t, path, FileSetOptions{})
+		set.Add("x", "1", "y")
+		set.Close()
+		before, _ := os.ReadFile(path + ".wal")
+
+		_, err := OpenFileSet(path, JSONCodec[int](), FileSetOptions{})
+		expect(t, errors.Is(err, ErrCorruptFile), "Expected ErrCorruptFile, got %v", err)
+		after, _ := os.ReadFile(path + ".wal")
+		expect(t, bytes.Equal(before, after), "Expected the log to be left intact, got %d bytes of %d", len(after), len(before))
+
+		reopened := openTestFileSet(t, path, FileSetOptions{})
+		defer reopened.Close()
+		expect(t, reopened.Snapshot().Equals(New("x", "1", "y")), "Expected {x, 1, y}, got %s", reopened.Snapshot())
+	})
+}
@@ -1,5 +1,5 @@
 module github.com/daynemay/goset
 
-go 1.18
+go 1.23
 
 require golang.org/x/exp v0.0.0-20220414153411-bcd21879b8fd
@@ -0,0 +1,200 @@
+package goset
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// Hash returns a hash of theSet that does not depend on the order of its members, so that two sets
+// which are Equals have the same Hash. Members are hashed by value; as with sortComparable,
+// pointers and channels are identified by machine address, so such hashes are only stable
+// for the life of the process.
+func (theSet Set[T]) Hash() uint64 {
+	sum, _ := theSet.hashSums()
+	return mix64(sum ^ mix64(uint64(theSet.Count())))
+}
+
+// Fingerprint returns a string identifying the members of theSet, suitable for use as a cache key.
+// It is consistent with Equals in the same way as Hash, and combines the 64-bit hash of each member
+// in two ways, plus the Count, making accidental collisions between different sets less likely than
+// with Hash. Members whose 64-bit hashes collide still collide in the Fingerprint.
+func (theSet Set[T]) Fingerprint() string {
+	sum, xor := theSet.hashSums()
+	return fmt.Sprintf("%d:%016x%016x", theSet.Count(), sum, xor)
+}
+
+// hashSums combines the hash of each member in two different, order-independent ways.
+func (theSet Set[T]) hashSums() (sum, xor uint64) {
+	h := fnv.New64a()
+	for member := range theSet.members {
+		h.Reset()
+		hashValue(h, reflect.ValueOf(&member).Elem())
+		memberHash := h.Sum64()
+		sum += mix64(memberHash)
+		xor ^= mix64(memberHash ^ 0x9e3779b97f4a7c15)
+	}
+	return sum, xor
+}
+
+// mix64 is the splitmix64 finalizer, used to spread member hashes before they are combined.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// hashValue writes a representation of v to h such that values which compare equal with ==
+// write the same bytes. See compare for the equivalent ordering rules.
+func hashValue(h hash.Hash64, v reflect.Value) {
+	var buf [8]byte
+	writeUint := func(u uint64) {
+		binary.LittleEndian.PutUint64(buf[:], u)
+		h.Write(buf[:])
+	}
+	writeFloat := func(f float64) {
+		switch {
+		case f == 0:
+			f = 0 // +0 and -0 are ==, so must hash alike
+		case isNaN(f):
+			f = math.NaN()
+		}
+		writeUint(math.Float64bits(f))
+	}
+
+	if !v.IsValid() {
+		h.Write([]byte{0})
+		return
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.String:
+		s := v.String()
+		writeUint(uint64(len(s)))
+		h.Write([]byte(s))
+	case reflect.Float32, reflect.Float64:
+		writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		writeFloat(real(c))
+		writeFloat(imag(c))
+	case reflect.Bool:
+		if v.Bool() {
+			h.Write([]byte{1})
+		} else {
+			h.Write([]byte{0})
+		}
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Chan, reflect.Map, reflect.Func, reflect.Slice:
+		writeUint(uint64(v.Pointer()))
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			hashValue(h, v.Field(i))
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			hashValue(h, v.Index(i))
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			h.Write([]byte{0})
+			return
+		}
+		h.Write([]byte{1})
+		name := v.Elem().Type().String()
+		writeUint(uint64(len(name)))
+		h.Write([]byte(name))
+		hashValue(h, v.Elem())
+	}
+}
+
+// SetOfSets is a set whose members are themselves Sets, compared by set equality
+type SetOfSets[T comparable] struct {
+	buckets map[uint64][]Set[T]
+}
+
+// NewSetOfSets returns a new SetOfSets, optionally initialized with some members
+func NewSetOfSets[T comparable](members ...Set[T]) SetOfSets[T] {
+	newSet := SetOfSets[T]{
+		buckets: map[uint64][]Set[T]{},
+	}
+	return newSet.Add(members...)
+}
+
+// Add adds Sets to theSet, ignoring any equal to a Set already present. Each Set is cloned,
+// so that later changes to it do not affect theSet.
+func (theSet SetOfSets[T]) Add(members ...Set[T]) SetOfSets[T] {
+	for _, member := range members {
+		key := member.Hash()
+		if theSet.find(key, member) >= 0 {
+			continue
+		}
+		theSet.buckets[key] = append(theSet.buckets[key], member.Clone())
+	}
+	return theSet
+}
+
+// Remove removes Sets from theSet, ignoring any that are not present
+func (theSet SetOfSets[T]) Remove(members ...Set[T]) SetOfSets[T] {
+	for _, member := range members {
+		key := member.Hash()
+		idx := theSet.find(key, member)
+		if idx < 0 {
+			continue
+		}
+		bucket := theSet.buckets[key]
+		bucket = append(bucket[:idx], bucket[idx+1:]...)
+		if len(bucket) == 0 {
+			delete(theSet.buckets, key)
+		} else {
+			theSet.buckets[key] = bucket
+		}
+	}
+	return theSet
+}
+
+// Contains returns a boolean indicating whether theSet contains a Set equal to each of members
+func (theSet SetOfSets[T]) Contains(members ...Set[T]) bool {
+	for _, member := range members {
+		if theSet.find(member.Hash(), member) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// AsList returns a slice of the Sets in theSet
+func (theSet SetOfSets[T]) AsList() []Set[T] {
+	list := make([]Set[T], 0, theSet.Count())
+	for _, bucket := range theSet.buckets {
+		list = append(list, bucket...)
+	}
+	return list
+}
+
+// Count returns the number of Sets in theSet
+func (theSet SetOfSets[T]) Count() int {
+	count := 0
+	for _, bucket := range theSet.buckets {
+		count += len(bucket)
+	}
+	return count
+}
+
+// find returns the index of the Set equal to member in the bucket for key, or -1 if there is none.
+func (theSet SetOfSets[T]) find(key uint64, member Set[T]) int {
+	for idx, candidate := range theSet.buckets[key] {
+		if candidate.Equals(member) {
+			return idx
+		}
+	}
+	return -1
+}
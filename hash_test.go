@@ -0,0 +1,97 @@
+package goset
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSet_Hash(t *testing.T) {
+	t.Run("Equal sets have the same Hash regardless of insertion order", func(t *testing.T) {
+		a := New("ryu", "ken", "guile", "chun-li")
+		b := New("chun-li", "guile", "ken", "ryu")
+		expect(t, a.Hash() == b.Hash(), "Expected equal sets to have equal hashes")
+	})
+
+	t.Run("Different sets have different Hashes", func(t *testing.T) {
+		a := New("ryu", "ken")
+		b := New("ryu", "guile")
+		expect(t, a.Hash() != b.Hash(), "Expected different sets to have different hashes")
+		expect(t, New[string]().Hash() != New("").Hash(), "Expected {} and {\"\"} to have different hashes")
+	})
+
+	t.Run("Hash does not depend on the Comparator", func(t *testing.T) {
+		a := NewWithComparator(byPersonAge, people...)
+		b := NewWithComparator(byPersonName, people...)
+		expect(t, a.Hash() == b.Hash(), "Expected sets with different comparators to have equal hashes")
+	})
+
+	t.Run("Hash treats +0 and -0 alike, as Equals does", func(t *testing.T) {
+		a := New(0.0, 1.0)
+		b := New(math.Copysign(0, -1), 1.0)
+		expect(t, a.Equals(b), "sanity check: expected a and b to be equal")
+		expect(t, a.Hash() == b.Hash(), "Expected +0 and -0 to hash alike")
+	})
+
+	t.Run("Hash distinguishes dynamic types of interface members", func(t *testing.T) {
+		a := New[interface{}](1)
+		b := New[interface{}](int64(1))
+		expect(t, a.Hash() != b.Hash(), "Expected int and int64 members to hash differently")
+	})
+
+	t.Run("Hash reflects mutation", func(t *testing.T) {
+		set := New(1, 2)
+		before := set.Hash()
+		set.Add(3)
+		expect(t, set.Hash() != before, "Expected Hash to change after Add")
+	})
+}
+
+func TestSet_Fingerprint(t *testing.T) {
+	t.Run("Equal sets have the same Fingerprint", func(t *testing.T) {
+		a := New(people...)
+		b := New(greg, chris, lara, kim, rick, jeff)
+		expect(t, a.Fingerprint() == b.Fingerprint(), "Expected equal sets to have equal fingerprints")
+	})
+
+	t.Run("Fingerprint is stable", func(t *testing.T) {
+		actual := New("balrog", "cammy").Fingerprint()
+		expected := New("cammy", "balrog").Fingerprint()
+		expect(t, actual == expected, "Expected %s, got %s", expected, actual)
+		expect(t, actual[:2] == "2:", "Expected Fingerprint to begin with the Count, got %s", actual)
+	})
+
+	t.Run("Different sets have different Fingerprints", func(t *testing.T) {
+		a := New(1, 2, 3)
+		b := New(1, 2, 4)
+		expect(t, a.Fingerprint() != b.Fingerprint(), "Expected different sets to have different fingerprints")
+	})
+}
+
+func TestSetOfSets(t *testing.T) {
+	t.Run("SetOfSets ignores sets equal to existing members", func(t *testing.T) {
+		sets := NewSetOfSets(New("ryu", "ken"), New("ken", "ryu"), New("guile"))
+		expect(t, sets.Count() == 2, "Expected 2 sets, got %v", sets.Count())
+	})
+
+	t.Run("SetOfSets Contains equal sets", func(t *testing.T) {
+		sets := NewSetOfSets(New("ryu", "ken"))
+		expect(t, sets.Contains(New("ken", "ryu")), "Expected SetOfSets to contain an equal set")
+		expect(t, !sets.Contains(New("ken")), "Expected SetOfSets not to contain a subset")
+		expect(t, !sets.Contains(New("ken", "ryu"), New("ken")), "Expected SetOfSets to require all arguments")
+	})
+
+	t.Run("SetOfSets is not affected by mutation of added sets", func(t *testing.T) {
+		member := New("ryu")
+		sets := NewSetOfSets(member)
+		member.Add("ken")
+		expect(t, sets.Contains(New("ryu")), "Expected SetOfSets to keep its own copy of members")
+	})
+
+	t.Run("SetOfSets Remove removes equal sets", func(t *testing.T) {
+		sets := NewSetOfSets(New(1, 2), New(3))
+		sets.Remove(New(2, 1), New(4))
+		expect(t, sets.Count() == 1, "Expected 1 set, got %v", sets.Count())
+		expect(t, !sets.Contains(New(1, 2)), "Expected removed set to be absent")
+		expect(t, len(sets.AsList()) == 1, "Expected AsList to return 1 set")
+	})
+}
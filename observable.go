@@ -0,0 +1,197 @@
+package goset
+
+import "sync"
+
+// EventKind identifies the kind of change described by an Event
+type EventKind int
+
+const (
+	// Added indicates that members were added to an ObservableSet
+	Added EventKind = iota
+	// Removed indicates that members were removed from an ObservableSet
+	Removed
+)
+
+// String returns a string representation of kind
+func (kind EventKind) String() string {
+	switch kind {
+	case Added:
+		return "Added"
+	case Removed:
+		return "Removed"
+	default:
+		return "EventKind(?)"
+	}
+}
+
+// Event describes a single change to an ObservableSet. A call to Add or Remove with several members
+// produces one Event listing every member that actually changed.
+type Event[T comparable] struct {
+	Kind    EventKind
+	Members []T
+}
+
+// ObservableSet wraps a Set, notifying subscribers when members are added or removed.
+// It is safe for concurrent use.
+type ObservableSet[T comparable] struct {
+	mu  sync.RWMutex
+	set Set[T]
+	// notifyMu orders the delivery of Events, and is held while callbacks run.
+	notifyMu sync.Mutex
+	// subscribersMu guards subscribers and nextID, and is never held while callbacks run, so that a
+	// callback may unsubscribe.
+	subscribersMu sync.Mutex
+	subscribers   map[int]func(Event[T])
+	nextID        int
+}
+
+// NewObservable returns a new ObservableSet wrapping set. set should not be modified directly afterwards.
+func NewObservable[T comparable](set Set[T]) *ObservableSet[T] {
+	return &ObservableSet[T]{
+		set:         set,
+		subscribers: map[int]func(Event[T]){},
+	}
+}
+
+// Subscribe registers callback to be called with each subsequent Event, and returns a function that
+// unsubscribes it. Events are delivered in order, one at a time, on the goroutine that made the change;
+// callback must not modify theSet, but may unsubscribe itself or other subscribers.
+func (theSet *ObservableSet[T]) Subscribe(callback func(Event[T])) (unsubscribe func()) {
+	theSet.subscribersMu.Lock()
+	defer theSet.subscribersMu.Unlock()
+	id := theSet.nextID
+	theSet.nextID++
+	theSet.subscribers[id] = callback
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			theSet.subscribersMu.Lock()
+			defer theSet.subscribersMu.Unlock()
+			delete(theSet.subscribers, id)
+		})
+	}
+}
+
+// SubscribeChan returns a channel receiving each subsequent Event, and a function that unsubscribes and
+// closes it. The channel has the given buffer size; once it is full, changes to theSet block until the
+// subscriber receives or unsubscribes, so a subscriber may stop reading and unsubscribe without draining.
+func (theSet *ObservableSet[T]) SubscribeChan(buffer int) (events <-chan Event[T], unsubscribe func()) {
+	ch := make(chan Event[T], buffer)
+	done := make(chan struct{})
+	// sendMu keeps ch from being closed during a send.
+	var sendMu sync.Mutex
+	closed := false
+	unsubscribeCallback := theSet.Subscribe(func(event Event[T]) {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		if closed {
+			return
+		}
+		select {
+		case ch <- event:
+		case <-done:
+		}
+	})
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			// Release any blocked send before waiting for it to finish.
+			close(done)
+			unsubscribeCallback()
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			closed = true
+			close(ch)
+		})
+	}
+}
+
+// Add adds members to theSet, ignoring those already present, and notifies subscribers of any that were added
+func (theSet *ObservableSet[T]) Add(members ...T) *ObservableSet[T] {
+	theSet.mu.Lock()
+	added := []T{}
+	for _, member := range members {
+		if !theSet.set.Contains(member) {
+			theSet.set.Add(member)
+			added = append(added, member)
+		}
+	}
+	theSet.notify(Event[T]{Kind: Added, Members: added})
+	return theSet
+}
+
+// Remove removes members from theSet, ignoring those not present, and notifies subscribers of any that were removed
+func (theSet *ObservableSet[T]) Remove(members ...T) *ObservableSet[T] {
+	theSet.mu.Lock()
+	removed := []T{}
+	for _, member := range members {
+		if theSet.set.Contains(member) {
+			theSet.set.Remove(member)
+			removed = append(removed, member)
+		}
+	}
+	theSet.notify(Event[T]{Kind: Removed, Members: removed})
+	return theSet
+}
+
+// notify delivers event to every subscriber, unless it has no Members. It must be called with mu
+// locked, and unlocks it once delivery is ordered after any earlier Events. A subscriber unsubscribed
+// by an earlier callback during delivery is skipped.
+func (theSet *ObservableSet[T]) notify(event Event[T]) {
+	if len(event.Members) == 0 {
+		theSet.mu.Unlock()
+		return
+	}
+	theSet.notifyMu.Lock()
+	defer theSet.notifyMu.Unlock()
+	theSet.mu.Unlock()
+
+	theSet.subscribersMu.Lock()
+	ids := make([]int, 0, len(theSet.subscribers))
+	for id := range theSet.subscribers {
+		ids = append(ids, id)
+	}
+	theSet.subscribersMu.Unlock()
+	for _, id := range ids {
+		theSet.subscribersMu.Lock()
+		callback, ok := theSet.subscribers[id]
+		theSet.subscribersMu.Unlock()
+		if ok {
+			callback(event)
+		}
+	}
+}
+
+// Contains returns a boolean indicating whether theSet contains all the given values
+func (theSet *ObservableSet[T]) Contains(values ...T) bool {
+	theSet.mu.RLock()
+	defer theSet.mu.RUnlock()
+	return theSet.set.Contains(values...)
+}
+
+// Count returns the set cardinality of theSet
+func (theSet *ObservableSet[T]) Count() int {
+	theSet.mu.RLock()
+	defer theSet.mu.RUnlock()
+	return theSet.set.Count()
+}
+
+// AsList returns a slice of values in theSet
+func (theSet *ObservableSet[T]) AsList() []T {
+	theSet.mu.RLock()
+	defer theSet.mu.RUnlock()
+	return theSet.set.AsList()
+}
+
+// Snapshot returns a copy of the current members of theSet, sharing its Comparator
+func (theSet *ObservableSet[T]) Snapshot() Set[T] {
+	theSet.mu.RLock()
+	defer theSet.mu.RUnlock()
+	return theSet.set.cloneWithComparator()
+}
+
+// String returns a string representation of theSet
+func (theSet *ObservableSet[T]) String() string {
+	return theSet.Snapshot().String()
+}
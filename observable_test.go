@@ -0,0 +1,151 @@
+package goset
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestObservableSet(t *testing.T) {
+	t.Run("Subscribers receive one batch Event per Add, listing only new members", func(t *testing.T) {
+		set := NewObservable(New("ryu"))
+		events := []Event[string]{}
+		set.Subscribe(func(event Event[string]) {
+			events = append(events, event)
+		})
+		set.Add("ryu", "ken", "guile")
+		expected := []Event[string]{{Kind: Added, Members: []string{"ken", "guile"}}}
+		expect(t, reflect.DeepEqual(events, expected), "Expected %v, got %v", expected, events)
+		expect(t, set.Contains("ryu", "ken", "guile"), "Expected set to contain added members")
+	})
+
+	t.Run("Subscribers receive Removed Events", func(t *testing.T) {
+		set := NewObservable(New("ryu", "ken"))
+		events := []Event[string]{}
+		set.Subscribe(func(event Event[string]) {
+			events = append(events, event)
+		})
+		set.Remove("ken", "vega")
+		expected := []Event[string]{{Kind: Removed, Members: []string{"ken"}}}
+		expect(t, reflect.DeepEqual(events, expected), "Expected %v, got %v", expected, events)
+		expect(t, set.Count() == 1, "Expected 1 member to remain, got %v", set.Count())
+	})
+
+	t.Run("No Event is emitted when nothing changes", func(t *testing.T) {
+		set := NewObservable(New("ryu"))
+		calls := 0
+		set.Subscribe(func(event Event[string]) { calls++ })
+		set.Add("ryu")
+		set.Remove("ken")
+		expect(t, calls == 0, "Expected no Events, got %v", calls)
+	})
+
+	t.Run("Unsubscribed callbacks receive no further Events", func(t *testing.T) {
+		set := NewObservable(New[int]())
+		calls := 0
+		unsubscribe := set.Subscribe(func(event Event[int]) { calls++ })
+		set.Add(1)
+		unsubscribe()
+		unsubscribe()
+		set.Add(2)
+		expect(t, calls == 1, "Expected 1 Event before unsubscribing, got %v", calls)
+	})
+
+	t.Run("Callbacks can unsubscribe themselves", func(t *testing.T) {
+		set := NewObservable(New[int]())
+		calls := 0
+		var unsubscribe func()
+		unsubscribe = set.Subscribe(func(event Event[int]) {
+			calls++
+			unsubscribe()
+		})
+		finished := make(chan struct{})
+		go func() {
+			set.Add(1)
+			set.Add(2)
+			close(finished)
+		}()
+		select {
+		case <-finished:
+		case <-time.After(time.Second):
+			t.Fatal("Expected Add to return when a callback unsubscribes itself")
+		}
+		expect(t, calls == 1, "Expected 1 Event before unsubscribing, got %v", calls)
+	})
+
+	t.Run("Channel subscribers receive Events in order", func(t *testing.T) {
+		set := NewObservable(New[int]())
+		events, unsubscribe := set.SubscribeChan(2)
+		set.Add(1, 2)
+		set.Remove(1)
+		expect(t, reflect.DeepEqual(<-events, Event[int]{Kind: Added, Members: []int{1, 2}}), "Expected Added {1, 2} first")
+		expect(t, reflect.DeepEqual(<-events, Event[int]{Kind: Removed, Members: []int{1}}), "Expected Removed {1} second")
+		unsubscribe()
+		_, open := <-events
+		expect(t, !open, "Expected channel to be closed after unsubscribing")
+		set.Add(3)
+	})
+
+	t.Run("Channel subscribers can unsubscribe without draining", func(t *testing.T) {
+		set := NewObservable(New[int]())
+		_, unsubscribe := set.SubscribeChan(0)
+		blocked := make(chan struct{})
+		go func() {
+			set.Add(1)
+			close(blocked)
+		}()
+		time.Sleep(10 * time.Millisecond)
+		unsubscribe()
+		select {
+		case <-blocked:
+		case <-time.After(time.Second):
+			t.Fatal("Expected Add to be released by unsubscribing")
+		}
+		finished := make(chan struct{})
+		go func() {
+			set.Add(2)
+			close(finished)
+		}()
+		select {
+		case <-finished:
+		case <-time.After(time.Second):
+			t.Fatal("Expected Add not to block after unsubscribing")
+		}
+		expect(t, set.Contains(1, 2), "Expected both members to be added")
+	})
+
+	t.Run("Concurrent Adds each notify exactly once per new member", func(t *testing.T) {
+		set := NewObservable(New[int]())
+		var mu sync.Mutex
+		seen := New[int]()
+		notified := 0
+		set.Subscribe(func(event Event[int]) {
+			mu.Lock()
+			defer mu.Unlock()
+			seen.Add(event.Members...)
+			notified += len(event.Members)
+		})
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for member := 0; member < 100; member++ {
+					set.Add(member)
+				}
+			}()
+		}
+		wg.Wait()
+		expect(t, notified == 100, "Expected 100 notifications, got %v", notified)
+		expect(t, seen.Equals(set.Snapshot()), "Expected notifications to match the final set")
+	})
+
+	t.Run("Snapshot is independent of the ObservableSet", func(t *testing.T) {
+		set := NewObservable(New("ryu"))
+		snapshot := set.Snapshot()
+		set.Add("ken")
+		expect(t, !snapshot.Contains("ken"), "Expected Snapshot not to change")
+		expect(t, set.String() == "goset.Set[string]{ken, ryu}", "Unexpected String(): %s", set)
+	})
+}
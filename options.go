@@ -0,0 +1,111 @@
+package goset
+
+import "iter"
+
+// An Option configures a Set created by NewWithOptions
+type Option[T comparable] func(*setOptions[T])
+
+// setOptions collects the configuration of a new Set before it is allocated.
+type setOptions[T comparable] struct {
+	comparator Comparator[T]
+	capacity   int
+	fills      []func(Set[T])
+}
+
+// NewWithOptions returns a new Set configured by opts, which are applied in order
+func NewWithOptions[T comparable](opts ...Option[T]) Set[T] {
+	config := setOptions[T]{}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	newSet := Set[T]{
+		members:    make(map[T]struct{}, config.capacity),
+		comparator: config.comparator,
+	}
+	for _, fill := range config.fills {
+		fill(newSet)
+	}
+	return newSet
+}
+
+// WithComparator sets the Comparator defining a sort function for members
+func WithComparator[T comparable](cmp Comparator[T]) Option[T] {
+	return func(config *setOptions[T]) {
+		config.comparator = cmp
+	}
+}
+
+// WithCompareFunc sets a three-way CompareFunc defining a sort function for members
+func WithCompareFunc[T comparable](compare CompareFunc[T]) Option[T] {
+	return func(config *setOptions[T]) {
+		config.comparator = nil
+		if compare != nil {
+			config.comparator = compare.Comparator()
+		}
+	}
+}
+
+// WithCapacity hints that the Set will hold at least capacity members, so that they can be added
+// without reallocation. Options adding a known number of members add to the hint themselves.
+func WithCapacity[T comparable](capacity int) Option[T] {
+	return func(config *setOptions[T]) {
+		config.capacity += capacity
+	}
+}
+
+// WithMembers adds members to the Set
+func WithMembers[T comparable](members ...T) Option[T] {
+	return func(config *setOptions[T]) {
+		config.capacity += len(members)
+		config.fills = append(config.fills, func(set Set[T]) {
+			set.Add(members...)
+		})
+	}
+}
+
+// WithSeq adds each value yielded by seq to the Set
+func WithSeq[T comparable](seq iter.Seq[T]) Option[T] {
+	return func(config *setOptions[T]) {
+		config.fills = append(config.fills, func(set Set[T]) {
+			for member := range seq {
+				set.Add(member)
+			}
+		})
+	}
+}
+
+// FromMapKeys adds the keys of m to the Set
+func FromMapKeys[K comparable, V any](m map[K]V) Option[K] {
+	return func(config *setOptions[K]) {
+		config.capacity += len(m)
+		config.fills = append(config.fills, func(set Set[K]) {
+			for key := range m {
+				set.Add(key)
+			}
+		})
+	}
+}
+
+// FromMapValues adds the values of m to the Set
+func FromMapValues[K, V comparable](m map[K]V) Option[V] {
+	return func(config *setOptions[V]) {
+		config.capacity += len(m)
+		config.fills = append(config.fills, func(set Set[V]) {
+			for _, value := range m {
+				set.Add(value)
+			}
+		})
+	}
+}
+
+// FromSliceFunc adds the result of calling f on each element of slice to the Set
+func FromSliceFunc[S any, T comparable](slice []S, f func(S) T) Option[T] {
+	return func(config *setOptions[T]) {
+		config.capacity += len(slice)
+		config.fills = append(config.fills, func(set Set[T]) {
+			for _, element := range slice {
+				set.Add(f(element))
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package goset
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	t.Run("NewWithOptions with no options returns an empty set", func(t *testing.T) {
+		set := NewWithOptions[string]()
+		expect(t, set.Count() == 0, "Expected an empty set, got %s", set)
+		set.Add("ryu")
+		expect(t, set.Contains("ryu"), "Expected the set to be usable")
+	})
+
+	t.Run("WithMembers adds members, and may be repeated", func(t *testing.T) {
+		set := NewWithOptions(WithMembers("ryu", "ken"), WithMembers("ken", "guile"))
+		expect(t, set.Equals(New("ryu", "ken", "guile")), "Expected {guile, ken, ryu}, got %s", set)
+	})
+
+	t.Run("WithComparator sets the sort order", func(t *testing.T) {
+		set := NewWithOptions(WithComparator(byPersonAge), WithMembers(people...))
+		sorted := set.AsSortedList()
+		expected := []person{kim, greg, chris, lara, rick, jeff}
+		expect(t, reflect.DeepEqual(sorted, expected), "Expected %v, got %v", expected, sorted)
+	})
+
+	t.Run("WithCompareFunc sets the sort order", func(t *testing.T) {
+		set := NewWithOptions(WithCompareFunc(comparePersonAge), WithMembers(people...))
+		expect(t, set.AsSortedList()[0] == kim, "Expected kim first, got %v", set.AsSortedList())
+	})
+
+	t.Run("WithCapacity accumulates a capacity hint", func(t *testing.T) {
+		config := setOptions[int]{}
+		for _, opt := range []Option[int]{WithCapacity[int](10), WithMembers(1, 2, 3), FromMapKeys(map[int]bool{4: true})} {
+			opt(&config)
+		}
+		expect(t, config.capacity == 14, "Expected capacity 14, got %v", config.capacity)
+	})
+
+	t.Run("WithSeq adds each value of a sequence", func(t *testing.T) {
+		set := NewWithOptions(WithSeq(slices.Values([]string{"ryu", "ken", "ryu"})))
+		expect(t, set.Equals(New("ryu", "ken")), "Expected {ken, ryu}, got %s", set)
+	})
+
+	t.Run("FromMapKeys and FromMapValues add keys and values", func(t *testing.T) {
+		ages := map[string]int{"Jeff": 58, "Kim": 3, "Kimberly": 3}
+		keys := NewWithOptions(FromMapKeys(ages))
+		expect(t, keys.Equals(New("Jeff", "Kim", "Kimberly")), "Expected keys, got %s", keys)
+		values := NewWithOptions(FromMapValues(ages))
+		expect(t, values.Equals(New(58, 3)), "Expected values, got %s", values)
+		expect(t, NewWithOptions(WithSeq(maps.Keys(ages))).Equals(keys), "Expected WithSeq(maps.Keys) to match FromMapKeys")
+	})
+
+	t.Run("FromSliceFunc adds the result of a function of each element", func(t *testing.T) {
+		set := NewWithOptions(FromSliceFunc(people, func(p person) string { return strings.ToLower(p.name) }))
+		expect(t, set.Equals(New("jeff", "rick", "kim", "lara", "chris", "greg")), "Expected lower-case names, got %s", set)
+	})
+}
@@ -0,0 +1,128 @@
+package goset
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// ParallelThreshold is the number of members below which ParallelUnion, ParallelIntersect and ParallelMinus
+// fall back to Union, Intersect and Minus, for which the cost of coordinating workers would outweigh the gain.
+// Only the membership tests are divided between workers: Go maps cannot be written concurrently, so each
+// result is built, pre-sized, on the calling goroutine, and that remains a large share of the cost.
+var ParallelThreshold = 50000
+
+// parallelCheckInterval is how many members a worker examines between checks for cancellation.
+const parallelCheckInterval = 1024
+
+// ParallelUnion returns the same result as a.Union(b), dividing the work between GOMAXPROCS workers.
+// It returns ctx.Err() if ctx is done before the work is complete. Only finding the members of the
+// smaller Set missing from the larger is divided; the result is still a copy of the larger Set built
+// on the calling goroutine, as much work as Union does, so ParallelUnion is not expected to beat Union.
+// It is for callers that need a Union they can cancel alongside ParallelIntersect and ParallelMinus.
+func ParallelUnion[T comparable](ctx context.Context, a, b Set[T]) (Set[T], error) {
+	if err := ctx.Err(); err != nil {
+		return Set[T]{}, err
+	}
+	smaller, larger := a, b
+	if smaller.Count() > larger.Count() {
+		smaller, larger = larger, smaller
+	}
+	if smaller.Count() < ParallelThreshold {
+		return a.Union(b), nil
+	}
+	extra, err := parallelFilter(ctx, smaller.AsList(), func(member T) bool {
+		_, ok := larger.members[member]
+		return !ok
+	})
+	if err != nil {
+		return Set[T]{}, err
+	}
+	union := larger.membersWithCapacity(larger.Count() + len(extra))
+	union.Add(extra...)
+	return union, nil
+}
+
+// ParallelIntersect returns the same result as a.Intersect(b), dividing the work between GOMAXPROCS workers.
+// It returns ctx.Err() if ctx is done before the work is complete.
+func ParallelIntersect[T comparable](ctx context.Context, a, b Set[T]) (Set[T], error) {
+	if err := ctx.Err(); err != nil {
+		return Set[T]{}, err
+	}
+	smaller, larger := a, b
+	if smaller.Count() > larger.Count() {
+		smaller, larger = larger, smaller
+	}
+	if smaller.Count() < ParallelThreshold {
+		return a.Intersect(b), nil
+	}
+	common, err := parallelFilter(ctx, smaller.AsList(), func(member T) bool {
+		_, ok := larger.members[member]
+		return ok
+	})
+	if err != nil {
+		return Set[T]{}, err
+	}
+	return New(common...), nil
+}
+
+// ParallelMinus returns the same result as a.Minus(b), dividing the work between GOMAXPROCS workers.
+// It returns ctx.Err() if ctx is done before the work is complete.
+func ParallelMinus[T comparable](ctx context.Context, a, b Set[T]) (Set[T], error) {
+	if err := ctx.Err(); err != nil {
+		return Set[T]{}, err
+	}
+	if a.Count() < ParallelThreshold {
+		return a.Minus(b), nil
+	}
+	difference, err := parallelFilter(ctx, a.AsList(), func(member T) bool {
+		_, ok := b.members[member]
+		return !ok
+	})
+	if err != nil {
+		return Set[T]{}, err
+	}
+	return New(difference...), nil
+}
+
+// parallelFilter returns the members for which keep returns true, calling keep from GOMAXPROCS
+// goroutines, each working through its own contiguous chunk of members.
+func parallelFilter[T comparable](ctx context.Context, members []T, keep func(T) bool) ([]T, error) {
+	workers := runtime.GOMAXPROCS(0)
+	chunkSize := (len(members) + workers - 1) / workers
+	results := make([][]T, workers)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		start := min(worker*chunkSize, len(members))
+		end := min(start+chunkSize, len(members))
+		wg.Add(1)
+		go func(worker int, chunk []T) {
+			defer wg.Done()
+			kept := []T{}
+			for idx, member := range chunk {
+				if idx%parallelCheckInterval == 0 && ctx.Err() != nil {
+					return
+				}
+				if keep(member) {
+					kept = append(kept, member)
+				}
+			}
+			results[worker] = kept
+		}(worker, members[start:end])
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	count := 0
+	for _, kept := range results {
+		count += len(kept)
+	}
+	all := make([]T, 0, count)
+	for _, kept := range results {
+		all = append(all, kept...)
+	}
+	return all, nil
+}
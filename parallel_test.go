@@ -0,0 +1,99 @@
+package goset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// withParallelThreshold runs test with ParallelThreshold set to threshold.
+func withParallelThreshold(threshold int, test func()) {
+	previous := ParallelThreshold
+	ParallelThreshold = threshold
+	defer func() { ParallelThreshold = previous }()
+	test()
+}
+
+func TestParallelOperations(t *testing.T) {
+	a, b := New[int](), New[int]()
+	for member := 0; member < 20000; member++ {
+		a.Add(member)
+		b.Add(member + 5000)
+	}
+	b.Add(-1)
+
+	operations := map[string]struct {
+		parallel   func(context.Context, Set[int], Set[int]) (Set[int], error)
+		sequential func(Set[int], Set[int]) Set[int]
+	}{
+		"ParallelUnion":     {ParallelUnion[int], Set[int].Union},
+		"ParallelIntersect": {ParallelIntersect[int], Set[int].Intersect},
+		"ParallelMinus":     {ParallelMinus[int], Set[int].Minus},
+	}
+
+	for name, operation := range operations {
+		for _, threshold := range []int{0, 1 << 30} {
+			t.Run(fmt.Sprintf("%s matches the sequential result with threshold %d", name, threshold), func(t *testing.T) {
+				withParallelThreshold(threshold, func() {
+					for _, pair := range [][2]Set[int]{{a, b}, {b, a}, {a, New[int]()}, {New[int](), a}} {
+						actual, err := operation.parallel(context.Background(), pair[0], pair[1])
+						expected := operation.sequential(pair[0], pair[1])
+						expect(t, err == nil, "Unexpected error %v", err)
+						expect(t, actual.Equals(expected), "Expected %v members, got %v", expected.Count(), actual.Count())
+					}
+				})
+			})
+		}
+
+		t.Run(name+" returns the context error when cancelled", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			withParallelThreshold(0, func() {
+				_, err := operation.parallel(ctx, a, b)
+				expect(t, errors.Is(err, context.Canceled), "Expected context.Canceled, got %v", err)
+			})
+		})
+	}
+
+	t.Run("ParallelUnion orders its result like Union", func(t *testing.T) {
+		withParallelThreshold(0, func() {
+			a, b := NewWithComparator(byPersonAge, jeff, kim), New(greg)
+			union, _ := ParallelUnion(context.Background(), a, b)
+			expected := a.Union(b).AsSortedList()
+			expect(t, reflect.DeepEqual(union.AsSortedList(), expected), "Expected %v, got %v", expected, union.AsSortedList())
+		})
+	})
+}
+
+// benchmarkParallel compares parallel with the sequential operation it divides between workers.
+func benchmarkParallel(b *testing.B, sequential func(Set[string], Set[string]) Set[string], parallel func(context.Context, Set[string], Set[string]) (Set[string], error)) {
+	first, second := benchmarkSets(1000000)
+	b.Run("Sequential", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sequential(first, second)
+		}
+	})
+	b.Run("Parallel", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := parallel(context.Background(), first, second); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkParallelIntersect(b *testing.B) {
+	benchmarkParallel(b, Set[string].Intersect, ParallelIntersect[string])
+}
+
+func BenchmarkParallelMinus(b *testing.B) {
+	benchmarkParallel(b, Set[string].Minus, ParallelMinus[string])
+}
+
+func BenchmarkParallelUnion(b *testing.B) {
+	benchmarkParallel(b, Set[string].Union, ParallelUnion[string])
+}
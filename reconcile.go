@@ -0,0 +1,154 @@
+package goset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// A ReconcileFunc creates or deletes a single member while reconciling
+type ReconcileFunc[T comparable] func(ctx context.Context, member T) error
+
+// Reconciler drives an observed Set towards a desired Set, calling Create for each member that is
+// desired but not observed, and Delete for each member that is observed but not desired
+type Reconciler[T comparable] struct {
+	// Create is called for each member to be added. A nil Create always succeeds.
+	Create ReconcileFunc[T]
+	// Delete is called for each member to be removed. A nil Delete always succeeds.
+	Delete ReconcileFunc[T]
+	// Concurrency is the maximum number of Create and Delete calls in progress at once. Values below 1 mean 1.
+	Concurrency int
+	// MaxRetries is the number of times a failed Create or Delete is retried before giving up.
+	MaxRetries int
+	// RetryDelay is the time waited before each retry.
+	RetryDelay time.Duration
+}
+
+// ReconcileResult reports the outcome of Reconciler.Reconcile
+type ReconcileResult[T comparable] struct {
+	// Diff holds the changes that were attempted
+	Diff SetDiff[T]
+	// Converged holds the observed Set after applying every successful Create and Delete
+	Converged Set[T]
+	// Errors holds the last error for each member whose Create or Delete did not succeed
+	Errors map[T]error
+}
+
+// NewReconciler returns a Reconciler calling create and remove one member at a time, without retries
+func NewReconciler[T comparable](create, remove ReconcileFunc[T]) Reconciler[T] {
+	return Reconciler[T]{
+		Create:      create,
+		Delete:      remove,
+		Concurrency: 1,
+	}
+}
+
+// Reconcile computes the Diff from observed to desired and applies it using Create and Delete.
+// It returns once every member has succeeded, exhausted its retries, or ctx is done; members not
+// attempted because ctx is done are reported in Errors with ctx.Err(). observed is not modified.
+func (r Reconciler[T]) Reconcile(ctx context.Context, desired, observed Set[T]) ReconcileResult[T] {
+	result := ReconcileResult[T]{
+		Diff:      Diff(observed, desired),
+		Converged: observed.cloneWithComparator(),
+		Errors:    map[T]error{},
+	}
+
+	concurrency := r.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	slots := make(chan struct{}, concurrency)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	run := func(member T, action ReconcileFunc[T], onSuccess func(...T) Set[T]) {
+		// Check ctx first, as select chooses at random between a free slot and a done ctx.
+		if err := ctx.Err(); err != nil {
+			mu.Lock()
+			result.Errors[member] = err
+			mu.Unlock()
+			return
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			mu.Lock()
+			result.Errors[member] = ctx.Err()
+			mu.Unlock()
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			err := r.attempt(ctx, member, action)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				result.Errors[member] = err
+			} else {
+				onSuccess(member)
+			}
+		}()
+	}
+
+	for _, member := range result.Diff.Removed.AsSortedList() {
+		run(member, r.Delete, result.Converged.Remove)
+	}
+	for _, member := range result.Diff.Added.AsSortedList() {
+		run(member, r.Create, result.Converged.Add)
+	}
+	wg.Wait()
+
+	return result
+}
+
+// attempt calls action for member, retrying up to MaxRetries times after failure.
+func (r Reconciler[T]) attempt(ctx context.Context, member T, action ReconcileFunc[T]) error {
+	if action == nil {
+		return nil
+	}
+	var err error
+	for try := 0; try <= r.MaxRetries; try++ {
+		if try > 0 {
+			select {
+			case <-time.After(r.RetryDelay):
+			case <-ctx.Done():
+				return errors.Join(err, ctx.Err())
+			}
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return errors.Join(err, ctxErr)
+		}
+		if err = action(ctx, member); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// IsConverged returns a boolean indicating whether every Create and Delete succeeded
+func (theResult ReconcileResult[T]) IsConverged() bool {
+	return len(theResult.Errors) == 0
+}
+
+// Err returns nil if theResult IsConverged, or otherwise an error combining each member's error,
+// in AsSortedList order
+func (theResult ReconcileResult[T]) Err() error {
+	if theResult.IsConverged() {
+		return nil
+	}
+	failed := NewWithComparator(theResult.Converged.comparator)
+	for member := range theResult.Errors {
+		failed.Add(member)
+	}
+	errs := make([]error, 0, failed.Count())
+	for _, member := range failed.AsSortedList() {
+		errs = append(errs, fmt.Errorf("%v: %w", member, theResult.Errors[member]))
+	}
+	return errors.Join(errs...)
+}
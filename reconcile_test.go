@@ -0,0 +1,133 @@
+package goset
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReconciler_Reconcile(t *testing.T) {
+	desired := New("ryu", "ken", "cammy")
+	observed := New("ryu", "guile", "vega")
+
+	t.Run("Reconcile creates missing members and deletes unwanted ones", func(t *testing.T) {
+		created, deleted := New[string](), New[string]()
+		var mu sync.Mutex
+		reconciler := NewReconciler(
+			func(ctx context.Context, member string) error {
+				mu.Lock()
+				defer mu.Unlock()
+				created.Add(member)
+				return nil
+			},
+			func(ctx context.Context, member string) error {
+				mu.Lock()
+				defer mu.Unlock()
+				deleted.Add(member)
+				return nil
+			},
+		)
+		result := reconciler.Reconcile(context.Background(), desired, observed)
+		expect(t, created.Equals(New("ken", "cammy")), "Expected Create for {cammy, ken}, got %s", created)
+		expect(t, deleted.Equals(New("guile", "vega")), "Expected Delete for {guile, vega}, got %s", deleted)
+		expect(t, result.IsConverged(), "Expected result to be converged, got %v", result.Err())
+		expect(t, result.Converged.Equals(desired), "Expected Converged = %s, got %s", desired, result.Converged)
+		expect(t, observed.Equals(New("ryu", "guile", "vega")), "Expected observed not to be modified")
+	})
+
+	t.Run("Reconcile reports per-member errors and leaves failed members unconverged", func(t *testing.T) {
+		failure := errors.New("boom")
+		reconciler := NewReconciler(
+			func(ctx context.Context, member string) error {
+				if member == "ken" {
+					return failure
+				}
+				return nil
+			},
+			func(ctx context.Context, member string) error {
+				if member == "vega" {
+					return failure
+				}
+				return nil
+			},
+		)
+		result := reconciler.Reconcile(context.Background(), desired, observed)
+		expect(t, !result.IsConverged(), "Expected result not to be converged")
+		expect(t, len(result.Errors) == 2, "Expected 2 errors, got %v", result.Errors)
+		expect(t, errors.Is(result.Errors["ken"], failure), "Expected ken to fail, got %v", result.Errors["ken"])
+		expected := New("ryu", "cammy", "vega")
+		expect(t, result.Converged.Equals(expected), "Expected Converged = %s, got %s", expected, result.Converged)
+		expect(t, errors.Is(result.Err(), failure), "Expected Err() to wrap member errors")
+		expect(t, result.Err().Error() == "ken: boom\nvega: boom", "Expected Err() in sorted order, got %q", result.Err())
+	})
+
+	t.Run("Reconcile retries failures", func(t *testing.T) {
+		var attempts atomic.Int32
+		reconciler := NewReconciler(func(ctx context.Context, member string) error {
+			if attempts.Add(1) < 3 {
+				return errors.New("not yet")
+			}
+			return nil
+		}, nil)
+		reconciler.MaxRetries = 2
+		result := reconciler.Reconcile(context.Background(), New("ken"), New[string]())
+		expect(t, result.IsConverged(), "Expected retries to succeed, got %v", result.Err())
+		expect(t, attempts.Load() == 3, "Expected 3 attempts, got %v", attempts.Load())
+	})
+
+	t.Run("Reconcile bounds concurrency", func(t *testing.T) {
+		var active, peak atomic.Int32
+		reconciler := NewReconciler(func(ctx context.Context, member int) error {
+			now := active.Add(1)
+			for {
+				seen := peak.Load()
+				if now <= seen || peak.CompareAndSwap(seen, now) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			active.Add(-1)
+			return nil
+		}, nil)
+		reconciler.Concurrency = 3
+		result := reconciler.Reconcile(context.Background(), New(1, 2, 3, 4, 5, 6, 7, 8, 9, 10), New[int]())
+		expect(t, result.IsConverged(), "Expected result to be converged")
+		expect(t, peak.Load() <= 3, "Expected at most 3 concurrent calls, got %v", peak.Load())
+	})
+
+	t.Run("Reconcile stops on context cancellation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		var calls atomic.Int32
+		count := func(ctx context.Context, member int) error {
+			calls.Add(1)
+			return nil
+		}
+		reconciler := NewReconciler(count, count)
+		reconciler.Concurrency = 3
+		result := reconciler.Reconcile(ctx, New(1, 2, 3), New(4))
+		expect(t, calls.Load() == 0, "Expected no callbacks to run, got %d", calls.Load())
+		expect(t, len(result.Errors) == 4, "Expected every member to report an error, got %v", result.Errors)
+		for member, err := range result.Errors {
+			expect(t, errors.Is(err, context.Canceled), "Expected %v to report cancellation, got %v", member, err)
+		}
+		expect(t, result.Converged.Equals(New(4)), "Expected Converged to be unchanged, got %s", result.Converged)
+	})
+
+	t.Run("Reconcile does not retry once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls atomic.Int32
+		reconciler := NewReconciler(func(ctx context.Context, member int) error {
+			calls.Add(1)
+			cancel()
+			return errors.New("failed")
+		}, nil)
+		reconciler.MaxRetries = 5
+		result := reconciler.Reconcile(ctx, New(1), New[int]())
+		expect(t, calls.Load() == 1, "Expected a single call, got %d", calls.Load())
+		expect(t, errors.Is(result.Errors[1], context.Canceled), "Expected cancellation, got %v", result.Errors[1])
+	})
+}
@@ -0,0 +1,196 @@
+package goset
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Relation describes how one Set relates to another
+type Relation string
+
+const (
+	// RelationEqual means the two Sets are Equals
+	RelationEqual Relation = "equal"
+	// RelationProperSubset means the first Set IsProperSubsetOf the second
+	RelationProperSubset Relation = "proper-subset"
+	// RelationProperSuperset means the first Set IsProperSupersetOf the second
+	RelationProperSuperset Relation = "proper-superset"
+	// RelationDisjoint means the two non-empty Sets have no members in common
+	RelationDisjoint Relation = "disjoint"
+	// RelationOverlap means the two Sets have some, but not all, members in common
+	RelationOverlap Relation = "overlap"
+)
+
+// SetRelation describes the Relation of the Set named A to the Set named B, and how many members they share
+type SetRelation struct {
+	A        string   `json:"a"`
+	B        string   `json:"b"`
+	Relation Relation `json:"relation"`
+	Overlap  int      `json:"overlap"`
+}
+
+// HasseEdge is an edge of a Hasse diagram, in which the Set named Lower is a proper subset of the Set
+// named Upper, with no other Set in between
+type HasseEdge struct {
+	Lower string `json:"lower"`
+	Upper string `json:"upper"`
+}
+
+// RelationshipReport describes the relationships between a collection of named Sets, as returned by Relationships
+type RelationshipReport struct {
+	// Sizes holds the Count of each named Set
+	Sizes map[string]int `json:"sizes"`
+	// Classes groups the names of Sets that are equal to each other, each group and the groups themselves
+	// being in name order. The first name in each group represents it in Hasse.
+	Classes [][]string `json:"classes"`
+	// Pairs holds the relation of each pair of Sets, with A before B in name order
+	Pairs []SetRelation `json:"pairs"`
+	// Hasse holds the edges of the Hasse diagram of the containment lattice of Classes
+	Hasse []HasseEdge `json:"hasse"`
+}
+
+// Relationships analyzes which of the named sets are subsets, supersets, equal to or disjoint from each other
+func Relationships[T comparable](sets map[string]Set[T]) RelationshipReport {
+	names := make([]string, 0, len(sets))
+	for name := range sets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	report := RelationshipReport{
+		Sizes:   map[string]int{},
+		Classes: [][]string{},
+		Pairs:   []SetRelation{},
+		Hasse:   []HasseEdge{},
+	}
+	for _, name := range names {
+		report.Sizes[name] = sets[name].Count()
+	}
+
+	for i, a := range names {
+		for _, b := range names[i+1:] {
+			setA, setB := sets[a], sets[b]
+			relation := SetRelation{A: a, B: b, Overlap: setA.Intersect(setB).Count()}
+			switch {
+			case setA.Equals(setB):
+				relation.Relation = RelationEqual
+			case setA.IsProperSubsetOf(setB):
+				relation.Relation = RelationProperSubset
+			case setB.IsProperSubsetOf(setA):
+				relation.Relation = RelationProperSuperset
+			case relation.Overlap == 0:
+				relation.Relation = RelationDisjoint
+			default:
+				relation.Relation = RelationOverlap
+			}
+			report.Pairs = append(report.Pairs, relation)
+		}
+	}
+
+	// Group equal sets into classes, represented by their first name.
+	classOf := map[string]int{}
+	for _, name := range names {
+		for idx, class := range report.Classes {
+			if sets[class[0]].Equals(sets[name]) {
+				report.Classes[idx] = append(class, name)
+				classOf[name] = idx
+				break
+			}
+		}
+		if _, ok := classOf[name]; !ok {
+			classOf[name] = len(report.Classes)
+			report.Classes = append(report.Classes, []string{name})
+		}
+	}
+
+	// A proper subset relation between classes is an edge of the Hasse diagram unless another class lies between.
+	for _, lower := range report.Classes {
+		for _, upper := range report.Classes {
+			lowerSet, upperSet := sets[lower[0]], sets[upper[0]]
+			if !lowerSet.IsProperSubsetOf(upperSet) {
+				continue
+			}
+			covered := true
+			for _, between := range report.Classes {
+				betweenSet := sets[between[0]]
+				if lowerSet.IsProperSubsetOf(betweenSet) && betweenSet.IsProperSubsetOf(upperSet) {
+					covered = false
+					break
+				}
+			}
+			if covered {
+				report.Hasse = append(report.Hasse, HasseEdge{Lower: lower[0], Upper: upper[0]})
+			}
+		}
+	}
+	sort.Slice(report.Hasse, func(i, j int) bool {
+		if report.Hasse[i].Lower != report.Hasse[j].Lower {
+			return report.Hasse[i].Lower < report.Hasse[j].Lower
+		}
+		return report.Hasse[i].Upper < report.Hasse[j].Upper
+	})
+
+	return report
+}
+
+// String returns a human-readable text representation of theReport
+func (theReport RelationshipReport) String() string {
+	var sb strings.Builder
+	sb.WriteString("sets:\n")
+	for _, class := range theReport.Classes {
+		for _, name := range class {
+			sb.WriteString(fmt.Sprintf("  %s (%d)\n", name, theReport.Sizes[name]))
+		}
+	}
+	sb.WriteString("relations:\n")
+	for _, pair := range theReport.Pairs {
+		var description string
+		switch pair.Relation {
+		case RelationEqual:
+			description = "is equal to"
+		case RelationProperSubset:
+			description = "is a proper subset of"
+		case RelationProperSuperset:
+			description = "is a proper superset of"
+		case RelationDisjoint:
+			description = "is disjoint from"
+		default:
+			description = "overlaps"
+		}
+		sb.WriteString(fmt.Sprintf("  %s %s %s (%d in common)\n", pair.A, description, pair.B, pair.Overlap))
+	}
+	sb.WriteString("containment:\n")
+	for _, edge := range theReport.Hasse {
+		sb.WriteString(fmt.Sprintf("  %s < %s\n", edge.Lower, edge.Upper))
+	}
+	return sb.String()
+}
+
+// JSON returns a JSON representation of theReport
+func (theReport RelationshipReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(theReport, "", "  ")
+}
+
+// DOT returns a Graphviz DOT representation of the Hasse diagram of theReport, with an edge from each
+// Set to the Sets covering it. Each node is labelled with the names of the equal Sets it represents.
+func (theReport RelationshipReport) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph relationships {\n")
+	sb.WriteString("  rankdir=BT;\n")
+	for _, class := range theReport.Classes {
+		label := fmt.Sprintf("%s (%d)", strings.Join(class, ", "), theReport.Sizes[class[0]])
+		sb.WriteString(fmt.Sprintf("  %s [label=%s];\n", dotQuote(class[0]), dotQuote(label)))
+	}
+	for _, edge := range theReport.Hasse {
+		sb.WriteString(fmt.Sprintf("  %s -> %s;\n", dotQuote(edge.Lower), dotQuote(edge.Upper)))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// dotQuote returns s as a double-quoted DOT ID.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
@@ -0,0 +1,95 @@
+package goset
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var permissionGroups = map[string]Set[string]{
+	"everyone": New("alice", "bob", "carol", "dave"),
+	"staff":    New("alice", "bob", "carol"),
+	"admins":   New("alice"),
+	"owners":   New("alice"),
+	"auditors": New("dave"),
+	"editors":  New("bob", "dave"),
+}
+
+func TestRelationships(t *testing.T) {
+	report := Relationships(permissionGroups)
+
+	t.Run("Relationships reports the size of each set", func(t *testing.T) {
+		expect(t, report.Sizes["everyone"] == 4 && report.Sizes["admins"] == 1, "Unexpected sizes %v", report.Sizes)
+	})
+
+	t.Run("Relationships classifies each pair", func(t *testing.T) {
+		relations := map[[2]string]SetRelation{}
+		for _, pair := range report.Pairs {
+			relations[[2]string{pair.A, pair.B}] = pair
+		}
+		expect(t, len(report.Pairs) == 15, "Expected 15 pairs, got %v", len(report.Pairs))
+		cases := map[[2]string]Relation{
+			{"admins", "owners"}:   RelationEqual,
+			{"admins", "staff"}:    RelationProperSubset,
+			{"everyone", "staff"}:  RelationProperSuperset,
+			{"admins", "auditors"}: RelationDisjoint,
+			{"editors", "staff"}:   RelationOverlap,
+		}
+		for pair, expected := range cases {
+			actual := relations[pair].Relation
+			expect(t, actual == expected, "Expected %v to be %v, got %v", pair, expected, actual)
+		}
+		expect(t, relations[[2]string{"editors", "staff"}].Overlap == 1, "Expected editors and staff to share 1 member")
+	})
+
+	t.Run("Relationships groups equal sets into classes", func(t *testing.T) {
+		expected := [][]string{{"admins", "owners"}, {"auditors"}, {"editors"}, {"everyone"}, {"staff"}}
+		expect(t, reflect.DeepEqual(report.Classes, expected), "Expected %v, got %v", expected, report.Classes)
+	})
+
+	t.Run("Relationships computes the Hasse diagram of containment", func(t *testing.T) {
+		expected := []HasseEdge{
+			{"admins", "staff"},
+			{"auditors", "editors"},
+			{"editors", "everyone"},
+			{"staff", "everyone"},
+		}
+		expect(t, reflect.DeepEqual(report.Hasse, expected), "Expected %v, got %v", expected, report.Hasse)
+	})
+
+	t.Run("The text report lists sets, relations and containment", func(t *testing.T) {
+		text := report.String()
+		for _, line := range []string{
+			"  owners (1)\n",
+			"  admins is equal to owners (1 in common)\n",
+			"  editors overlaps staff (1 in common)\n",
+			"  staff < everyone\n",
+		} {
+			expect(t, strings.Contains(text, line), "Expected report to contain %q:\n%s", line, text)
+		}
+	})
+
+	t.Run("The JSON report round-trips", func(t *testing.T) {
+		data, err := report.JSON()
+		expect(t, err == nil, "Unexpected error %v", err)
+		var decoded RelationshipReport
+		expect(t, json.Unmarshal(data, &decoded) == nil, "Expected valid JSON")
+		expect(t, reflect.DeepEqual(decoded, report), "Expected JSON to round-trip, got %+v", decoded)
+	})
+
+	t.Run("The DOT report draws the Hasse diagram", func(t *testing.T) {
+		dot := report.DOT()
+		expect(t, strings.HasPrefix(dot, "digraph relationships {\n"), "Unexpected DOT header:\n%s", dot)
+		expect(t, strings.Contains(dot, `"admins" [label="admins, owners (1)"];`), "Expected a node for the admins class:\n%s", dot)
+		expect(t, strings.Contains(dot, `"staff" -> "everyone";`), "Expected an edge from staff to everyone:\n%s", dot)
+		expect(t, !strings.Contains(dot, `"admins" -> "everyone"`), "Expected no transitive edges:\n%s", dot)
+	})
+
+	t.Run("Empty and single-set inputs produce empty relations", func(t *testing.T) {
+		empty := Relationships(map[string]Set[int]{})
+		expect(t, len(empty.Pairs) == 0 && len(empty.Hasse) == 0, "Expected an empty report")
+		single := Relationships(map[string]Set[int]{"only": New(1)})
+		expect(t, len(single.Pairs) == 0 && len(single.Classes) == 1, "Expected a single class and no pairs")
+	})
+}
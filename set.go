@@ -19,30 +19,20 @@ type Set[T comparable] struct {
 	comparator Comparator[T]
 }
 
-// New returns a new Set, optionally initialized with some members
+// New returns a new Set, optionally initialized with some members.
+// See NewWithOptions for further configuration.
 func New[T comparable](members ...T) Set[T] {
-	newSet := Set[T]{
-		members:    map[T]struct{}{},
-		comparator: nil,
-	}
-	for _, entry := range members {
-		newSet.members[entry] = exists
-	}
-
-	return newSet
+	return NewWithOptions(WithMembers(members...))
 }
 
 // NewWithComparator return a new Set and accepts a Comparator defining a sort function for members
 func NewWithComparator[T comparable](cmp Comparator[T], members ...T) Set[T] {
-	newSet := Set[T]{
-		members:    map[T]struct{}{},
-		comparator: cmp,
-	}
-	for _, entry := range members {
-		newSet.members[entry] = exists
-	}
+	return NewWithOptions(WithComparator(cmp), WithMembers(members...))
+}
 
-	return newSet
+// NewWithCompareFunc returns a new Set and accepts a three-way CompareFunc defining a sort function for members
+func NewWithCompareFunc[T comparable](compare CompareFunc[T], members ...T) Set[T] {
+	return NewWithOptions(WithCompareFunc(compare), WithMembers(members...))
 }
 
 // String returns a string representation of theSet
@@ -69,8 +59,21 @@ func (theSet Set[T]) Add(members ...T) Set[T] {
 	return theSet
 }
 
-// Contains returns a boolean indicating whether theSet contains all the given strs
+// Remove removes members from a Set, ignoring any that are not present
+func (theSet Set[T]) Remove(members ...T) Set[T] {
+	for _, member := range members {
+		delete(theSet.members, member)
+	}
+	return theSet
+}
+
+// Contains returns a boolean indicating whether theSet contains all the given values (see ContainsAll)
 func (theSet Set[T]) Contains(values ...T) bool {
+	return theSet.ContainsAll(values...)
+}
+
+// ContainsAll returns a boolean indicating whether theSet contains all the given values
+func (theSet Set[T]) ContainsAll(values ...T) bool {
 	for _, s := range values {
 		if _, ok := theSet.members[s]; !ok {
 			return false
@@ -79,18 +82,43 @@ func (theSet Set[T]) Contains(values ...T) bool {
 	return true
 }
 
+// ContainsAny returns a boolean indicating whether theSet contains at least one of the given values
+func (theSet Set[T]) ContainsAny(values ...T) bool {
+	for _, s := range values {
+		if _, ok := theSet.members[s]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // Equals returns a boolean indicating whether theSet is set-equal to other
 func (theSet Set[T]) Equals(other Set[T]) bool {
-	if theSet.Count() != other.Count() {
-		return false
-	}
-	asList := theSet.AsList()
-	for _, entry := range asList {
-		if !other.Contains(entry) {
-			return false
+	return theSet.Count() == other.Count() && theSet.IsSubsetOf(other)
+}
+
+// Compare orders two Sets by lexicographic comparison of their members in the default ordering of
+// AsSortedList, returning -1, 0 or 1 in the style of cmp.Compare; a Set that is a prefix of the other
+// compares low. Any Comparator either Set has is ignored, so that Compare(a, b) == -Compare(b, a) and
+// Compare is a valid ordering for slices.SortFunc whichever Comparators the Sets carry. Compare returns
+// 0 when a.Equals(b). The converse fails only for members holding NaN, which compare equal although
+// NaN != NaN: Compare(New(math.NaN()), New(math.NaN())) is 0, but the Sets are not Equal.
+func Compare[T comparable](a, b Set[T]) int {
+	compareMember := memberCompareFunc[T]()
+	aList, bList := a.sortedListWith(nil), b.sortedListWith(nil)
+	for idx := 0; idx < len(aList) && idx < len(bList); idx++ {
+		if c := compareMember(aList[idx], bList[idx]); c != 0 {
+			return c
 		}
 	}
-	return true
+	switch {
+	case len(aList) < len(bList):
+		return -1
+	case len(aList) > len(bList):
+		return 1
+	default:
+		return 0
+	}
 }
 
 // AsList returns a slice of values in theSet
@@ -100,35 +128,44 @@ func (theSet Set[T]) AsList() []T {
 
 // AsSortedList returns a slice of values in theSet in a stable sorted order.
 func (theSet Set[T]) AsSortedList() []T {
-	if theSet.comparator != nil {
+	return theSet.sortedListWith(theSet.comparator)
+}
+
+// sortedListWith returns a slice of values in theSet sorted by cmp, or by the default ordering if cmp is nil.
+func (theSet Set[T]) sortedListWith(cmp Comparator[T]) []T {
+	if cmp != nil {
 		asList := theSet.AsList()
 		isLess := func(i, j int) bool {
-			return theSet.comparator(asList[i], asList[j])
+			return cmp(asList[i], asList[j])
 		}
 		sort.SliceStable(asList, isLess)
 		return asList
 	} else {
-		return sortComparable(theSet.AsList())
+		return sortMembers(theSet.AsList())
 	}
 }
 
 // Intersect returns a new Set resulting from the set intersection of theSet and other
 func (theSet Set[T]) Intersect(other Set[T]) Set[T] {
-	commonMembers := []T{}
-	for member := range theSet.members {
-		if other.Contains(member) {
-			commonMembers = append(commonMembers, member)
+	smaller, larger := theSet, other
+	if smaller.Count() > larger.Count() {
+		smaller, larger = larger, smaller
+	}
+	intersection := NewWithOptions(WithCapacity[T](smaller.Count()))
+	for member := range smaller.members {
+		if _, ok := larger.members[member]; ok {
+			intersection.members[member] = exists
 		}
 	}
-	return New(commonMembers...)
+	return intersection
 }
 
 // Minus returns a new set representing the set difference theSet - other
 func (theSet Set[T]) Minus(other Set[T]) Set[T] {
-	difference := New[T]()
+	difference := NewWithOptions(WithCapacity[T](theSet.Count()))
 	for member := range theSet.members {
-		if !other.Contains(member) {
-			difference.Add(member)
+		if _, ok := other.members[member]; !ok {
+			difference.members[member] = exists
 		}
 	}
 	return difference
@@ -136,30 +173,81 @@ func (theSet Set[T]) Minus(other Set[T]) Set[T] {
 
 // Clone returns a copy of this Set
 func (theSet Set[T]) Clone() Set[T] {
-	return New(theSet.AsList()...)
+	return theSet.membersWithCapacity(theSet.Count())
+}
+
+// cloneWithComparator returns a copy of theSet sharing its Comparator, which Clone does not.
+func (theSet Set[T]) cloneWithComparator() Set[T] {
+	return theSet.cloneWithCapacity(theSet.Count())
+}
+
+// cloneWithCapacity returns a copy of theSet, sharing its Comparator, with room for at least capacity members.
+func (theSet Set[T]) cloneWithCapacity(capacity int) Set[T] {
+	clone := theSet.membersWithCapacity(capacity)
+	clone.comparator = theSet.comparator
+	return clone
+}
+
+// membersWithCapacity returns a Set of the members of theSet, without its Comparator, with room for at
+// least capacity members.
+func (theSet Set[T]) membersWithCapacity(capacity int) Set[T] {
+	set := NewWithOptions(WithCapacity[T](capacity))
+	for member := range theSet.members {
+		set.members[member] = exists
+	}
+	return set
 }
 
 // Union returns a new Set resulting from the set union of theSet and other
 func (theSet Set[T]) Union(other Set[T]) Set[T] {
-	union := theSet.Clone()
-	union.Add(other.AsList()...)
+	union := theSet.membersWithCapacity(theSet.Count() + other.Count())
+	for member := range other.members {
+		union.members[member] = exists
+	}
 	return union
 }
 
+// Grow returns a copy of theSet, sharing its Comparator, with room for at least n more members
+// without further allocation. Go maps cannot grow in place, so like Clone, the copy does not share
+// members with theSet. It always copies every member, even when n is zero or negative.
+func (theSet Set[T]) Grow(n int) Set[T] {
+	return theSet.cloneWithCapacity(theSet.Count() + max(n, 0))
+}
+
+// Compact is Clone, except that the copy shares theSet's Comparator. Go maps never shrink, so theSet
+// itself keeps the storage it had before a mass removal; the copy is sized for the members it holds,
+// and replacing theSet with it releases that storage.
+func (theSet Set[T]) Compact() Set[T] {
+	return theSet.cloneWithCapacity(theSet.Count())
+}
+
+// IsSubsetOf returns a boolean indicating whether every member of theSet is in other.
+// It does not allocate, and returns as soon as a member of theSet is found missing from other.
 func (theSet Set[T]) IsSubsetOf(other Set[T]) bool {
-	return theSet.Intersect(other).Equals(theSet)
+	if theSet.Count() > other.Count() {
+		return false
+	}
+	for member := range theSet.members {
+		if _, ok := other.members[member]; !ok {
+			return false
+		}
+	}
+	return true
 }
 
+// IsProperSubsetOf returns a boolean indicating whether theSet IsSubsetOf other, and other has more members
 func (theSet Set[T]) IsProperSubsetOf(other Set[T]) bool {
-	return theSet.IsSubsetOf(other) && !theSet.Equals(other)
+	return theSet.Count() < other.Count() && theSet.IsSubsetOf(other)
 }
 
+// IsSupersetOf returns a boolean indicating whether every member of other is in theSet
 func (theSet Set[T]) IsSupersetOf(other Set[T]) bool {
 	return other.IsSubsetOf(theSet)
 }
 
+// IsProperSupersetOf returns a boolean indicating whether theSet IsSupersetOf other, and theSet has more members
 func (theSet Set[T]) IsProperSupersetOf(other Set[T]) bool {
-	return theSet.IsSupersetOf(other) && !theSet.Equals(other)
+	return other.IsProperSubsetOf(theSet)
 }
 
 // Count returns the set cardinality of theSet
@@ -0,0 +1,126 @@
+package goset
+
+import (
+	"fmt"
+	"testing"
+)
+
+// benchmarkSets returns two Sets of size members, overlapping by half.
+func benchmarkSets(size int) (Set[string], Set[string]) {
+	a, b := New[string](), New[string]()
+	for member := 0; member < size; member++ {
+		a.Add(fmt.Sprint(member))
+		b.Add(fmt.Sprint(member + size/2))
+	}
+	return a, b
+}
+
+func BenchmarkSet_Union(b *testing.B) {
+	first, second := benchmarkSets(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		first.Union(second)
+	}
+}
+
+func BenchmarkSet_Intersect(b *testing.B) {
+	first, second := benchmarkSets(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		first.Intersect(second)
+	}
+}
+
+func BenchmarkSet_Intersect_small_with_large(b *testing.B) {
+	large, _ := benchmarkSets(100000)
+	small := New("1", "2", "3")
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		large.Intersect(small)
+	}
+}
+
+func BenchmarkSet_Minus(b *testing.B) {
+	first, second := benchmarkSets(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		first.Minus(second)
+	}
+}
+
+func BenchmarkSet_Clone(b *testing.B) {
+	set, _ := benchmarkSets(10000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set.Clone()
+	}
+}
+
+func BenchmarkSet_Add_with_capacity(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		set := NewWithOptions(WithCapacity[int](10000))
+		for member := 0; member < 10000; member++ {
+			set.Add(member)
+		}
+	}
+}
+
+func BenchmarkSet_Add_without_capacity(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		set := New[int]()
+		for member := 0; member < 10000; member++ {
+			set.Add(member)
+		}
+	}
+}
+
+func BenchmarkSet_Equals(b *testing.B) {
+	first, _ := benchmarkSets(10000)
+	second := first.Clone()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		first.Equals(second)
+	}
+}
+
+func BenchmarkSet_IsSubsetOf(b *testing.B) {
+	first, second := benchmarkSets(10000)
+	subset := first.Intersect(second)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		subset.IsSubsetOf(first)
+	}
+}
+
+// listEquals is the AsList-based Equals that IsSubsetOf previously relied on.
+func listEquals[T comparable](theSet, other Set[T]) bool {
+	if theSet.Count() != other.Count() {
+		return false
+	}
+	for _, entry := range theSet.AsList() {
+		if !other.Contains(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+// BenchmarkSet_IsSubsetOf_via_Intersect measures the Intersect-then-Equals approach IsSubsetOf used previously.
+func BenchmarkSet_IsSubsetOf_via_Intersect(b *testing.B) {
+	first, second := benchmarkSets(10000)
+	subset := first.Intersect(second)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		listEquals(subset.Intersect(first), subset)
+	}
+}
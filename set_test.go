@@ -2,7 +2,9 @@ package goset
 
 import (
 	"fmt"
+	"math"
 	"reflect"
+	"slices"
 	"testing"
 )
 
@@ -512,3 +514,158 @@ func TestSet_IsProperSupersetOf(t *testing.T) {
 		expect(t, !super.IsSupersetOf(sub), "Element in %s should prevent %s from being a superset", sub, super)
 	})
 }
+
+func TestCompare(t *testing.T) {
+	t.Run("Equal sets compare equal", func(t *testing.T) {
+		a := New("ryu", "ken", "guile")
+		b := New("guile", "ryu", "ken")
+		expect(t, Compare(a, b) == 0, "Expected Compare(%s, %s) == 0", a, b)
+	})
+
+	t.Run("Two empty sets compare equal", func(t *testing.T) {
+		expect(t, Compare(New[string](), New[string]()) == 0, "Expected empty sets to compare equal")
+	})
+
+	t.Run("Sets of NaN compare equal without being Equal", func(t *testing.T) {
+		a, b := New(math.NaN()), New(math.NaN())
+		expect(t, Compare(a, b) == 0, "Expected Compare(%s, %s) == 0", a, b)
+		expect(t, !a.Equals(b), "Expected %s not to equal %s, as NaN != NaN", a, b)
+	})
+
+	t.Run("Sets compare by their first differing member", func(t *testing.T) {
+		a := New("balrog", "cammy")
+		b := New("ryu", "balrog")
+		expect(t, Compare(a, b) < 0, "Expected %s < %s", a, b)
+		expect(t, Compare(b, a) > 0, "Expected %s > %s", b, a)
+	})
+
+	t.Run("A set that is a prefix of another compares low", func(t *testing.T) {
+		a := New(1, 2)
+		b := New(1, 2, 3)
+		expect(t, Compare(a, b) < 0, "Expected %s < %s", a, b)
+		expect(t, Compare(b, a) > 0, "Expected %s > %s", b, a)
+		expect(t, Compare(New[int](), a) < 0, "Expected the empty set to compare low")
+	})
+
+	t.Run("Compare ignores comparators, so is antisymmetric", func(t *testing.T) {
+		byAge := NewWithComparator(byPersonAge, kim, jeff)
+		byName := NewWithComparator(byPersonName, greg, chris)
+		plain := New(greg, chris)
+		expect(t, Compare(byAge, byName) == Compare(byAge, plain), "Expected the Comparator of %s to be ignored", byName)
+		expect(t, Compare(byAge, byName) == -Compare(byName, byAge), "Expected Compare(a, b) == -Compare(b, a)")
+		expect(t, Compare(byName, plain) == 0, "Expected sets with the same members to compare equal")
+	})
+
+	t.Run("Compare can be used to sort a slice of sets", func(t *testing.T) {
+		sets := []Set[string]{New("ken", "ryu"), New[string](), New("guile"), New("cammy", "ken")}
+		slices.SortFunc(sets, Compare[string])
+		actual := fmt.Sprint(sets)
+		expected := "[goset.Set[string]{} goset.Set[string]{cammy, ken} goset.Set[string]{guile} goset.Set[string]{ken, ryu}]"
+		expect(t, actual == expected, "Expected %s, got %s", expected, actual)
+	})
+}
+
+func TestSet_Remove(t *testing.T) {
+	t.Run("Removing a member should remove it from the set", func(t *testing.T) {
+		set := New("guile", "ken")
+		set.Remove("guile")
+		expect(t, !set.Contains("guile"), "Expect set not to contain removed member")
+		expect(t, set.Count() == 1, "Expect set to decrease in size after Remove()ing a member")
+	})
+
+	t.Run("Removing an absent member should not change the set", func(t *testing.T) {
+		set := New("guile")
+		set.Remove("ken")
+		expect(t, set.Equals(New("guile")), "Expect set to be unchanged")
+	})
+
+	t.Run("Remove returns the modified original set", func(t *testing.T) {
+		airForce := New("guile", "ken")
+		guile := airForce.Remove("ken")
+		expect(t, airForce.Equals(guile), "Expected Remove to return the modified, original set")
+	})
+}
+
+func TestSet_Grow(t *testing.T) {
+	t.Run("Grow keeps members and comparator", func(t *testing.T) {
+		set := NewWithComparator(byPersonAge, people...)
+		grown := set.Grow(100)
+		expect(t, grown.String() == set.String(), "Expected %s after Grow, got %s", set, grown)
+	})
+
+	t.Run("Grow returns a copy", func(t *testing.T) {
+		set := New(1, 2)
+		grown := set.Grow(10)
+		grown.Add(3)
+		expect(t, !set.Contains(3), "Expected the original not to share members with the grown copy")
+	})
+
+	// addsAllocate returns the allocations made adding 1000 new members to each of sets in turn.
+	addsAllocate := func(sets ...Set[int]) float64 {
+		run := 0
+		return testing.AllocsPerRun(len(sets)-1, func() {
+			set := sets[run]
+			run++
+			for member := 0; member < 1000; member++ {
+				set.Add(member)
+			}
+		})
+	}
+
+	t.Run("Adding new members up to the grown capacity does not allocate", func(t *testing.T) {
+		allocs := addsAllocate(New[int]().Grow(1000), New[int]().Grow(1000), New[int]().Grow(1000))
+		expect(t, allocs == 0, "Expected no allocations after Grow, got %v", allocs)
+		allocs = addsAllocate(New[int](), New[int](), New[int]())
+		expect(t, allocs > 0, "Expected adding to an ungrown set to allocate")
+	})
+}
+
+func TestSet_Compact(t *testing.T) {
+	t.Run("Compact keeps members and comparator", func(t *testing.T) {
+		set := NewWithComparator(byPersonAge, people...)
+		set.Remove(kim, greg)
+		compacted := set.Compact()
+		expect(t, compacted.String() == set.String(), "Expected %s after Compact, got %s", set, compacted)
+	})
+
+	t.Run("Compact returns a copy", func(t *testing.T) {
+		set := New(1, 2)
+		compacted := set.Compact()
+		compacted.Add(3)
+		expect(t, !set.Contains(3), "Expected the original not to share members with the compacted copy")
+	})
+}
+
+func TestSet_ContainsAll(t *testing.T) {
+	t.Run("ContainsAll requires every value", func(t *testing.T) {
+		set := New("balrog", "guile")
+		expect(t, set.ContainsAll("balrog", "guile"), "Expected set to contain balrog and guile")
+		expect(t, !set.ContainsAll("guile", "honda"), "Expected set not to contain guile-and-honda")
+		expect(t, set.ContainsAll(), "Expected set to contain all of no values")
+	})
+}
+
+func TestSet_ContainsAny(t *testing.T) {
+	t.Run("ContainsAny requires at least one value", func(t *testing.T) {
+		set := New("balrog", "guile")
+		expect(t, set.ContainsAny("guile", "honda"), "Expected set to contain guile-or-honda")
+		expect(t, !set.ContainsAny("ken", "honda"), "Expected set not to contain ken-or-honda")
+		expect(t, !set.ContainsAny(), "Expected set not to contain any of no values")
+	})
+}
+
+func TestSet_comparisons_do_not_allocate(t *testing.T) {
+	a := New("ryu", "ken", "guile", "cammy")
+	b := New("ryu", "ken", "guile", "cammy", "vega")
+	checks := map[string]func(){
+		"Equals":             func() { a.Equals(b) },
+		"IsSubsetOf":         func() { a.IsSubsetOf(b) },
+		"IsProperSubsetOf":   func() { a.IsProperSubsetOf(b) },
+		"IsSupersetOf":       func() { b.IsSupersetOf(a) },
+		"IsProperSupersetOf": func() { b.IsProperSupersetOf(a) },
+	}
+	for name, check := range checks {
+		allocs := testing.AllocsPerRun(10, check)
+		expect(t, allocs == 0, "Expected %s not to allocate, got %v allocations", name, allocs)
+	}
+}
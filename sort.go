@@ -1,23 +1,120 @@
 package goset
 
 import (
+	"cmp"
 	"reflect"
+	"slices"
 	"sort"
+	"unsafe"
 )
 
+// sortMembers returns members in sorted order, and may reorder the slice it is given. When the underlying
+// type of T is a string, integer or float type, including named types such as `type UserID string`,
+// members are sorted in place with slices.Sort, the path being chosen once from the kind of T rather
+// than per comparison; everything else falls back to sortComparable. Both paths agree on the order,
+// including NaNs comparing low.
+func sortMembers[T comparable](members []T) []T {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.String:
+		sortAs[T, string](members)
+	case reflect.Int:
+		sortAs[T, int](members)
+	case reflect.Int8:
+		sortAs[T, int8](members)
+	case reflect.Int16:
+		sortAs[T, int16](members)
+	case reflect.Int32:
+		sortAs[T, int32](members)
+	case reflect.Int64:
+		sortAs[T, int64](members)
+	case reflect.Uint:
+		sortAs[T, uint](members)
+	case reflect.Uint8:
+		sortAs[T, uint8](members)
+	case reflect.Uint16:
+		sortAs[T, uint16](members)
+	case reflect.Uint32:
+		sortAs[T, uint32](members)
+	case reflect.Uint64:
+		sortAs[T, uint64](members)
+	case reflect.Uintptr:
+		sortAs[T, uintptr](members)
+	case reflect.Float32:
+		sortAs[T, float32](members)
+	case reflect.Float64:
+		sortAs[T, float64](members)
+	default:
+		return sortComparable(members)
+	}
+	return members
+}
+
+// compareMembers compares two members according to the same rules as sortComparable.
+func compareMembers[T comparable](a, b T) int {
+	return compare(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem())
+}
+
+// memberCompareFunc returns a CompareFunc equivalent to compareMembers, which for members of an ordered
+// kind compares them without reflection, so without allocating.
+func memberCompareFunc[T comparable]() CompareFunc[T] {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.String:
+		return compareAs[T, string]
+	case reflect.Int:
+		return compareAs[T, int]
+	case reflect.Int8:
+		return compareAs[T, int8]
+	case reflect.Int16:
+		return compareAs[T, int16]
+	case reflect.Int32:
+		return compareAs[T, int32]
+	case reflect.Int64:
+		return compareAs[T, int64]
+	case reflect.Uint:
+		return compareAs[T, uint]
+	case reflect.Uint8:
+		return compareAs[T, uint8]
+	case reflect.Uint16:
+		return compareAs[T, uint16]
+	case reflect.Uint32:
+		return compareAs[T, uint32]
+	case reflect.Uint64:
+		return compareAs[T, uint64]
+	case reflect.Uintptr:
+		return compareAs[T, uintptr]
+	case reflect.Float32:
+		return compareAs[T, float32]
+	case reflect.Float64:
+		return compareAs[T, float64]
+	default:
+		return compareMembers[T]
+	}
+}
+
+// compareAs compares a and b as O, without the use of reflection. O must be the underlying type of T.
+func compareAs[T comparable, O cmp.Ordered](a, b T) int {
+	return cmp.Compare(*(*O)(unsafe.Pointer(&a)), *(*O)(unsafe.Pointer(&b)))
+}
+
+// sortAs sorts members in place as a []O, without the use of reflection. O must be the underlying type
+// of T, so that the two have the same memory layout and order.
+func sortAs[T comparable, O cmp.Ordered](members []T) {
+	slices.Sort(unsafe.Slice((*O)(unsafe.Pointer(unsafe.SliceData(members))), len(members)))
+}
+
 // sortComparable is adapted from the logic in fmtsort.Sort, which ensures consistent output from things like:
 //   fmt.Printf("%v", map[string]int{"c": 3, "a": 1, "b": 2})
 //
-// It takes [][T comparable] and returns []T.
+// It takes []T for any comparable T and returns a new []T.
 // The []T returned is in a stable sorted order according to the following rules.
 //
-// TODO(daynemay): look into the "issues raised by unorderable key values such as NaNs" (as mentioned in fmtsort.Sort)
-//
-// The ordering rules are more general than with Go's < operator:
+// The ordering rules are more general than with Go's < operator, and define a total order
+// over every comparable kind (compare never panics):
 //
 //  - when applicable, nil compares low
 //  - ints, floats, and strings order by <
-//  - NaN compares less than non-NaN floats
+//  - NaN compares less than non-NaN floats, and equal to other NaNs
+//  - +0 and -0 compare equal, as they do with ==
 //  - bool compares false before true
 //  - complex compares real, then imag
 //  - pointers compare by machine address
@@ -25,44 +122,50 @@ import (
 //  - structs compare each field in turn
 //  - arrays compare each element in turn
 //    Otherwise identical arrays compare by length.
-//  - interface values compare first by reflect.Type describing the concrete type
+//  - interface values compare first by the concrete type (see typeCompare)
 //    and then by concrete value as described in the previous rules.
 //
 func sortComparable[T comparable](members []T) []T {
 	count := len(members)
 
-	// convert to []reflect.Value for sort
-	var keyValues valueArray = make([]reflect.Value, 0, count)
-	for _, key := range members {
-		keyValues = append(keyValues, reflect.ValueOf(key))
+	// Take reflect.Values via a pointer so that an interface T (e.g. any) yields
+	// a value of kind Interface rather than an invalid Value for nil members.
+	sortable := sortableMembers[T]{
+		members: make([]T, count),
+		values:  make([]reflect.Value, count),
 	}
-
-	sort.Stable(keyValues)
-
-	// convert back to []T for return
-	keys := make([]T, 0, count)
-	for _, keyValue := range keyValues {
-		keys = append(keys, keyValue.Interface().(T))
+	copy(sortable.members, members)
+	for idx := range sortable.members {
+		sortable.values[idx] = reflect.ValueOf(&sortable.members[idx]).Elem()
 	}
 
-	return keys
+	sort.Stable(sortable)
+
+	return sortable.members
 }
 
-// The rest of this file implements sort.Interface for a []reflect.Value
-type valueArray []reflect.Value
+// The rest of this file implements sort.Interface for a []T. Each of values is an addressable view
+// of the member in the same slot, so only members need to be swapped.
+type sortableMembers[T comparable] struct {
+	members []T
+	values  []reflect.Value
+}
 
-func (o valueArray) Len() int           { return len(o) }
-func (o valueArray) Less(i, j int) bool { return compare(o[i], o[j]) < 0 }
-func (o valueArray) Swap(i, j int)      { o[i], o[j] = o[j], o[i] }
+func (o sortableMembers[T]) Len() int           { return len(o.members) }
+func (o sortableMembers[T]) Less(i, j int) bool { return compare(o.values[i], o.values[j]) < 0 }
+func (o sortableMembers[T]) Swap(i, j int)      { o.members[i], o.members[j] = o.members[j], o.members[i] }
 
-// compare compares two values of the same type. It returns -1, 0, 1
+// compare compares two values. It returns -1, 0, 1
 // according to whether a > b (1), a == b (0), or a < b (-1).
-// If the types differ, it returns -1.
-// See the comment on AsSortedList for the comparison rules.
+// If the types differ, the values are ordered by typeCompare.
+// See the comment on sortComparable for the comparison rules.
 func compare(aVal, bVal reflect.Value) int {
+	if !aVal.IsValid() || !bVal.IsValid() {
+		return invalidCompare(aVal, bVal)
+	}
 	aType, bType := aVal.Type(), bVal.Type()
 	if aType != bType {
-		return -1 // No good answer possible, but don't return 0: they're not equal.
+		return typeCompare(aType, bType)
 	}
 	switch aVal.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -154,14 +257,65 @@ func compare(aVal, bVal reflect.Value) int {
 		if c, ok := nilCompare(aVal, bVal); ok {
 			return c
 		}
-		c := compare(reflect.ValueOf(aVal.Elem().Type()), reflect.ValueOf(bVal.Elem().Type()))
-		if c != 0 {
+		return compare(aVal.Elem(), bVal.Elem())
+	case reflect.Map, reflect.Func, reflect.Slice:
+		// These cannot appear in a comparable type, but order them by identity rather than panic.
+		if c, ok := nilCompare(aVal, bVal); ok {
 			return c
 		}
-		return compare(aVal.Elem(), bVal.Elem())
+		ap, bp := aVal.Pointer(), bVal.Pointer()
+		switch {
+		case ap < bp:
+			return -1
+		case ap > bp:
+			return 1
+		default:
+			return 0
+		}
+	default:
+		return 0
+	}
+}
+
+// invalidCompare orders the zero reflect.Value, which compares low, against any other value.
+func invalidCompare(aVal, bVal reflect.Value) int {
+	switch {
+	case aVal.IsValid():
+		return 1
+	case bVal.IsValid():
+		return -1
 	default:
-		// Certain types cannot appear as keys (maps, funcs, slices), but be explicit.
-		panic("bad type in compare: " + aType.String())
+		return 0
+	}
+}
+
+// typeCompare orders two distinct types by name, then by package path. Distinct types that
+// share both (e.g. identically named types declared in different functions) fall back to the
+// machine address of their type descriptors, which is stable for the life of the process.
+func typeCompare(aType, bType reflect.Type) int {
+	if aType == bType {
+		return 0
+	}
+	if aType.String() != bType.String() {
+		if aType.String() < bType.String() {
+			return -1
+		}
+		return 1
+	}
+	if aType.PkgPath() != bType.PkgPath() {
+		if aType.PkgPath() < bType.PkgPath() {
+			return -1
+		}
+		return 1
+	}
+	ap, bp := reflect.ValueOf(aType).Pointer(), reflect.ValueOf(bType).Pointer()
+	switch {
+	case ap < bp:
+		return -1
+	case ap > bp:
+		return 1
+	default:
+		return 0
 	}
 }
 
@@ -183,11 +337,13 @@ func nilCompare(aVal, bVal reflect.Value) (int, bool) {
 	return 0, false
 }
 
-// floatCompare compares two floating-point values. NaNs compare low.
+// floatCompare compares two floating-point values. NaNs compare low, and equal to each other.
 func floatCompare(a, b float64) int {
 	switch {
+	case isNaN(a) && isNaN(b):
+		return 0
 	case isNaN(a):
-		return -1 // No good answer if b is a NaN so don't bother checking.
+		return -1
 	case isNaN(b):
 		return 1
 	case a < b:
@@ -0,0 +1,272 @@
+package goset
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+type sortPoint struct {
+	x, y int
+}
+
+type sortNested struct {
+	label string
+	value interface{}
+}
+
+// comparableSamples returns a pool of values of many different comparable kinds, wrapped in
+// interface{} so that compare must also order values by their dynamic type.
+func comparableSamples() []interface{} {
+	one, two := 1, 2
+	ch1, ch2 := make(chan int), make(chan int)
+	samples := []interface{}{
+		nil,
+		0, 1, -1, math.MaxInt64, math.MinInt64,
+		int8(-3), int32(7), uint(0), uint8(255), uint64(math.MaxUint64), uintptr(9),
+		0.0, math.Copysign(0, -1), 1.5, -1.5, math.Inf(1), math.Inf(-1), math.NaN(), math.NaN(),
+		float32(2.5), float32(math.NaN()),
+		complex(1, 2), complex(1, math.NaN()), complex(math.NaN(), 0), complex64(complex(0, -1)),
+		"", "a", "b", "ab",
+		true, false,
+		&one, &two, (*int)(nil),
+		ch1, ch2, (chan int)(nil),
+		sortPoint{1, 2}, sortPoint{2, 1}, sortPoint{1, 1},
+		[2]int{1, 2}, [2]int{2, 1}, [3]int{1, 2, 3},
+		[2]interface{}{1, "a"}, [2]interface{}{"a", 1}, [2]interface{}{nil, math.NaN()},
+		sortNested{"a", 1}, sortNested{"a", "1"}, sortNested{"a", nil}, sortNested{"a", sortNested{"b", 2.0}},
+		sortNested{"a", math.NaN()},
+	}
+	return samples
+}
+
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func valueOfSample(sample *interface{}) reflect.Value {
+	return reflect.ValueOf(sample).Elem()
+}
+
+func TestCompare_properties(t *testing.T) {
+	samples := comparableSamples()
+
+	t.Run("compare is reflexive", func(t *testing.T) {
+		for idx := range samples {
+			a := valueOfSample(&samples[idx])
+			c := compare(a, a)
+			expect(t, c == 0, "compare(%#v, itself) = %v, expected 0", samples[idx], c)
+		}
+	})
+
+	t.Run("compare is antisymmetric", func(t *testing.T) {
+		for i := range samples {
+			for j := range samples {
+				a, b := valueOfSample(&samples[i]), valueOfSample(&samples[j])
+				ab, ba := sign(compare(a, b)), sign(compare(b, a))
+				expect(t, ab == -ba, "compare(%#v, %#v) = %v but compare(%#v, %#v) = %v", samples[i], samples[j], ab, samples[j], samples[i], ba)
+			}
+		}
+	})
+
+	t.Run("compare is transitive", func(t *testing.T) {
+		for i := range samples {
+			for j := range samples {
+				for k := range samples {
+					a, b, c := valueOfSample(&samples[i]), valueOfSample(&samples[j]), valueOfSample(&samples[k])
+					ab, bc, ac := sign(compare(a, b)), sign(compare(b, c)), sign(compare(a, c))
+					if ab <= 0 && bc <= 0 {
+						expect(t, ac <= 0, "%#v <= %#v <= %#v, but compare(a, c) = %v", samples[i], samples[j], samples[k], ac)
+					}
+					if ab == 0 && bc == 0 {
+						expect(t, ac == 0, "%#v == %#v == %#v, but compare(a, c) = %v", samples[i], samples[j], samples[k], ac)
+					}
+				}
+			}
+		}
+	})
+}
+
+func TestSortComparable(t *testing.T) {
+	t.Run("sortComparable does not panic on a Set[interface{}] containing nil and mixed types", func(t *testing.T) {
+		set := New[interface{}](nil, 1, "one", 1.0, sortPoint{1, 1}, [1]int{1})
+		sorted := set.AsSortedList()
+		expect(t, len(sorted) == set.Count(), "Expected %v members, got %v", set.Count(), len(sorted))
+		expect(t, sorted[0] == nil, "Expected nil to sort first, got %v", sorted)
+	})
+
+	t.Run("sortComparable orders NaNs first", func(t *testing.T) {
+		set := New(3.0, math.NaN(), -1.0, math.NaN(), math.Inf(-1))
+		sorted := set.AsSortedList()
+		expect(t, isNaN(sorted[0]) && isNaN(sorted[1]), "Expected NaNs first, got %v", sorted)
+		expected := []float64{math.Inf(-1), -1.0, 3.0}
+		expect(t, reflect.DeepEqual(sorted[2:], expected), "Expected %v after NaNs, got %v", expected, sorted[2:])
+	})
+
+	t.Run("sortComparable gives the same order regardless of input order", func(t *testing.T) {
+		samples := comparableSamples()
+		expected := sortComparable(samples)
+		random := rand.New(rand.NewSource(1))
+		for round := 0; round < 20; round++ {
+			shuffled := append([]interface{}{}, samples...)
+			random.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+			actual := sortComparable(shuffled)
+			for idx := range expected {
+				a, b := valueOfSample(&actual[idx]), valueOfSample(&expected[idx])
+				expect(t, compare(a, b) == 0, "Order differs at %v: %#v vs %#v", idx, actual[idx], expected[idx])
+			}
+		}
+	})
+
+	t.Run("sortComparable does not modify its argument", func(t *testing.T) {
+		members := []int{3, 1, 2}
+		sortComparable(members)
+		expect(t, reflect.DeepEqual(members, []int{3, 1, 2}), "Expected argument to be unchanged, got %v", members)
+	})
+}
+
+func TestSortMembers(t *testing.T) {
+	t.Run("sortMembers agrees with sortComparable for floats, including NaN and infinities", func(t *testing.T) {
+		members := []float64{3.3, math.NaN(), math.Inf(1), -0.5, math.Inf(-1), math.NaN(), 0}
+		expected := sortComparable(members)
+		actual := sortMembers(append([]float64{}, members...))
+		for idx := range expected {
+			same := expected[idx] == actual[idx] || (isNaN(expected[idx]) && isNaN(actual[idx]))
+			expect(t, same, "Expected %v, got %v", expected, actual)
+		}
+	})
+
+	t.Run("sortMembers agrees with sortComparable for strings", func(t *testing.T) {
+		members := []string{"ryu", "", "Ken", "ken", "balrog", "ab", "a"}
+		expected := sortComparable(members)
+		actual := sortMembers(append([]string{}, members...))
+		expect(t, reflect.DeepEqual(expected, actual), "Expected %v, got %v", expected, actual)
+	})
+
+	t.Run("sortMembers sorts named ordered types without reflection", func(t *testing.T) {
+		type userID string
+		members := []userID{"carol", "", "alice", "bob"}
+		expected := sortComparable(members)
+		actual := sortMembers(slices.Clone(members))
+		expect(t, reflect.DeepEqual(expected, actual), "Expected %v, got %v", expected, actual)
+
+		type level float32
+		levels := []level{3, level(math.NaN()), -1, 2}
+		allocs := testing.AllocsPerRun(10, func() {
+			sortMembers(levels)
+		})
+		expect(t, allocs == 0, "Expected no allocations, got %v", allocs)
+		expect(t, isNaN(float64(levels[0])) && reflect.DeepEqual(levels[1:], []level{-1, 2, 3}), "Expected [NaN -1 2 3], got %v", levels)
+	})
+
+	t.Run("sortMembers falls back to sortComparable for other kinds", func(t *testing.T) {
+		type point struct{ x, y int }
+		actual := sortMembers([]point{{1, 2}, {0, 5}, {1, 1}})
+		expected := []point{{0, 5}, {1, 1}, {1, 2}}
+		expect(t, reflect.DeepEqual(expected, actual), "Expected %v, got %v", expected, actual)
+	})
+}
+
+func TestMemberCompareFunc(t *testing.T) {
+	t.Run("memberCompareFunc agrees with compareMembers for floats, including NaN and signed zeros", func(t *testing.T) {
+		type level float64
+		members := []level{level(math.NaN()), level(math.Inf(-1)), -1, level(math.Copysign(0, -1)), 0, 2, level(math.Inf(1))}
+		compare := memberCompareFunc[level]()
+		for _, a := range members {
+			for _, b := range members {
+				expect(t, compare(a, b) == compareMembers(a, b), "Expected compare(%v, %v) == %d, got %d", a, b, compareMembers(a, b), compare(a, b))
+			}
+		}
+	})
+
+	t.Run("memberCompareFunc compares ordered kinds without allocating", func(t *testing.T) {
+		type userID string
+		compare := memberCompareFunc[userID]()
+		allocs := testing.AllocsPerRun(10, func() {
+			compare("alice", "bob")
+		})
+		expect(t, allocs == 0, "Expected no allocations, got %v", allocs)
+	})
+}
+
+func benchmarkMembers(size int) []int {
+	random := rand.New(rand.NewSource(1))
+	return random.Perm(size)
+}
+
+func BenchmarkAsSortedList_ints(b *testing.B) {
+	set := New(benchmarkMembers(10000)...)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set.AsSortedList()
+	}
+}
+
+// BenchmarkBaselineSortComparable_ints measures the reflection-based sort AsSortedList used originally.
+func BenchmarkBaselineSortComparable_ints(b *testing.B) {
+	set := New(benchmarkMembers(10000)...)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		baselineSortComparable(set.AsList())
+	}
+}
+
+func BenchmarkAsSortedList_strings(b *testing.B) {
+	set := New[string]()
+	for _, member := range benchmarkMembers(10000) {
+		set.Add(fmt.Sprint(member))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		set.AsSortedList()
+	}
+}
+
+// BenchmarkBaselineSortComparable_strings measures the reflection-based sort AsSortedList used originally.
+func BenchmarkBaselineSortComparable_strings(b *testing.B) {
+	set := New[string]()
+	for _, member := range benchmarkMembers(10000) {
+		set.Add(fmt.Sprint(member))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		baselineSortComparable(set.AsList())
+	}
+}
+
+// baselineSortComparable is sortComparable as it was before sortMembers was introduced, kept as the
+// benchmark baseline: each member is boxed in a reflect.Value to be sorted, then unboxed again.
+func baselineSortComparable[T comparable](members []T) []T {
+	count := len(members)
+	var keyValues baselineValueArray = make([]reflect.Value, 0, count)
+	for _, key := range members {
+		keyValues = append(keyValues, reflect.ValueOf(key))
+	}
+	sort.Stable(keyValues)
+	keys := make([]T, 0, count)
+	for _, keyValue := range keyValues {
+		keys = append(keys, keyValue.Interface().(T))
+	}
+	return keys
+}
+
+type baselineValueArray []reflect.Value
+
+func (o baselineValueArray) Len() int           { return len(o) }
+func (o baselineValueArray) Less(i, j int) bool { return compare(o[i], o[j]) < 0 }
+func (o baselineValueArray) Swap(i, j int)      { o[i], o[j] = o[j], o[i] }
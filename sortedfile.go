@@ -0,0 +1,203 @@
+package goset
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// The layout of a sorted file is a header of sortedFileMagic, the format version, reserved flags and the
+// member count, followed by count+1 offsets and then the members themselves, concatenated in sorted
+// order. Member i occupies bytes offsets[i] to offsets[i+1] of the data that follows the offsets.
+// All integers are little-endian.
+const (
+	sortedFileMagic      = "GOSETSTR"
+	sortedFileVersion    = 1
+	sortedFileHeaderSize = len(sortedFileMagic) + 4 + 4 + 8
+)
+
+// SortedFile is a read-only Set[string] stored in a file written by WriteSortedFile. The file is mapped
+// into memory where the platform allows, so that opening it reads nothing beyond the header and
+// offsets, and Contains binary searches the members in place. It is safe for concurrent use, and
+// must be closed with Close.
+type SortedFile struct {
+	data    []byte
+	count   int
+	members []byte
+	unmap   func() error
+}
+
+// WriteSortedFile writes the members of set in sorted order to the file at path, replacing it atomically
+func WriteSortedFile(path string, set Set[string]) (err error) {
+	members := sortMembers(set.AsList())
+	temporary, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, temporary.Close(), os.Remove(temporary.Name()))
+		}
+	}()
+
+	w := bufio.NewWriter(temporary)
+	var header [sortedFileHeaderSize]byte
+	copy(header[:], sortedFileMagic)
+	binary.LittleEndian.PutUint32(header[len(sortedFileMagic):], sortedFileVersion)
+	binary.LittleEndian.PutUint64(header[len(sortedFileMagic)+8:], uint64(len(members)))
+	w.Write(header[:])
+	offset := uint64(0)
+	var buf [8]byte
+	for idx := 0; idx <= len(members); idx++ {
+		binary.LittleEndian.PutUint64(buf[:], offset)
+		w.Write(buf[:])
+		if idx < len(members) {
+			offset += uint64(len(members[idx]))
+		}
+	}
+	for _, member := range members {
+		w.WriteString(member)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := temporary.Sync(); err != nil {
+		return err
+	}
+	if err := temporary.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temporary.Name(), path)
+}
+
+// OpenSortedFile opens the file at path written by WriteSortedFile
+func OpenSortedFile(path string) (*SortedFile, error) {
+	data, unmap, err := mapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	theFile, err := newSortedFile(data)
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("%s: %w", path, err), unmap())
+	}
+	theFile.unmap = unmap
+	return theFile, nil
+}
+
+// newSortedFile checks the header and offsets of data, and returns a SortedFile reading from it.
+func newSortedFile(data []byte) (*SortedFile, error) {
+	corrupt := func(reason string) error {
+		return fmt.Errorf("%w: %s", ErrCorruptFile, reason)
+	}
+	if len(data) < sortedFileHeaderSize || string(data[:len(sortedFileMagic)]) != sortedFileMagic {
+		return nil, corrupt("not a sorted file")
+	}
+	if version := binary.LittleEndian.Uint32(data[len(sortedFileMagic):]); version != sortedFileVersion {
+		return nil, corrupt(fmt.Sprintf("unsupported version %d", version))
+	}
+	count := binary.LittleEndian.Uint64(data[len(sortedFileMagic)+8:])
+	if count >= uint64(len(data)-sortedFileHeaderSize)/8 {
+		return nil, corrupt("member count exceeds file size")
+	}
+	theFile := &SortedFile{
+		data:    data,
+		count:   int(count),
+		members: data[sortedFileHeaderSize+8*(int(count)+1):],
+	}
+	previous := uint64(0)
+	for idx := 0; idx <= theFile.count; idx++ {
+		offset := theFile.offset(idx)
+		if offset < previous || offset > uint64(len(theFile.members)) {
+			return nil, corrupt("offsets out of order")
+		}
+		previous = offset
+	}
+	return theFile, nil
+}
+
+// Contains returns a boolean indicating whether theFile contains all the given values
+func (theFile *SortedFile) Contains(values ...string) bool {
+	for _, value := range values {
+		idx := theFile.search(value)
+		if idx == theFile.count || string(theFile.member(idx)) != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the number of members in theFile
+func (theFile *SortedFile) Count() int {
+	return theFile.count
+}
+
+// All returns an iterator over the members of theFile in sorted order
+func (theFile *SortedFile) All() iter.Seq[string] {
+	return theFile.between(0, theFile.count)
+}
+
+// WithPrefix returns an iterator over the members of theFile beginning with prefix, in sorted order
+func (theFile *SortedFile) WithPrefix(prefix string) iter.Seq[string] {
+	start := theFile.search(prefix)
+	end := start + sort.Search(theFile.count-start, func(i int) bool {
+		member := theFile.member(start + i)
+		return len(member) < len(prefix) || string(member[:len(prefix)]) != prefix
+	})
+	return theFile.between(start, end)
+}
+
+// CountPrefix returns the number of members of theFile beginning with prefix
+func (theFile *SortedFile) CountPrefix(prefix string) int {
+	count := 0
+	for range theFile.WithPrefix(prefix) {
+		count++
+	}
+	return count
+}
+
+// Load returns the members of theFile as a new Set
+func (theFile *SortedFile) Load() Set[string] {
+	set := NewWithOptions(WithCapacity[string](theFile.count))
+	for member := range theFile.All() {
+		set.Add(member)
+	}
+	return set
+}
+
+// Close releases the memory mapping of theFile, after which it must not be used
+func (theFile *SortedFile) Close() error {
+	return theFile.unmap()
+}
+
+// search returns the index of the first member not less than value.
+func (theFile *SortedFile) search(value string) int {
+	return sort.Search(theFile.count, func(i int) bool {
+		return string(theFile.member(i)) >= value
+	})
+}
+
+// between returns an iterator over copies of the members from index start up to end.
+func (theFile *SortedFile) between(start, end int) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for idx := start; idx < end; idx++ {
+			if !yield(string(theFile.member(idx))) {
+				return
+			}
+		}
+	}
+}
+
+// member returns the bytes of member idx, which alias the file.
+func (theFile *SortedFile) member(idx int) []byte {
+	return theFile.members[theFile.offset(idx):theFile.offset(idx+1)]
+}
+
+// offset returns the offset of member idx within theFile.members.
+func (theFile *SortedFile) offset(idx int) uint64 {
+	return binary.LittleEndian.Uint64(theFile.data[sortedFileHeaderSize+8*idx:])
+}
@@ -0,0 +1,99 @@
+package goset
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// writeTestSortedFile writes set to a sorted file and opens it, closing it when the test finishes.
+func writeTestSortedFile(t *testing.T, set Set[string]) *SortedFile {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "set")
+	if err := WriteSortedFile(path, set); err != nil {
+		t.Fatalf("Unexpected error writing %s: %v", path, err)
+	}
+	theFile, err := OpenSortedFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error opening %s: %v", path, err)
+	}
+	t.Cleanup(func() { theFile.Close() })
+	return theFile
+}
+
+func TestSortedFile(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	set := New("", "apple", "apricot", "banana", "band", "bandana", "ban")
+	for i := 0; i < 500; i++ {
+		set.Add(strconv.Itoa(random.Intn(100000)))
+	}
+
+	t.Run("SortedFile Contains matches Set Contains", func(t *testing.T) {
+		theFile := writeTestSortedFile(t, set)
+		expect(t, theFile.Count() == set.Count(), "Expected Count %d, got %d", set.Count(), theFile.Count())
+		for _, value := range append(set.AsList(), "a", "bandanas", "zzz", "0", "apple ") {
+			expect(t, theFile.Contains(value) == set.Contains(value), "Expected Contains(%q) to be %t", value, set.Contains(value))
+		}
+		expect(t, theFile.Contains("apple", "ban"), "Expected SortedFile to contain apple and ban")
+		expect(t, !theFile.Contains("apple", "bandit"), "Expected SortedFile not to contain apple and bandit")
+		expect(t, theFile.Contains(), "Expected SortedFile to contain no values")
+	})
+
+	t.Run("SortedFile All and Load return every member", func(t *testing.T) {
+		theFile := writeTestSortedFile(t, set)
+		all := slices.Collect(theFile.All())
+		expect(t, slices.Equal(all, set.AsSortedList()), "Expected the sorted members, got %v", all)
+		expect(t, theFile.Load().Equals(set), "Expected Load to return the members")
+	})
+
+	t.Run("SortedFile WithPrefix returns the members with a prefix", func(t *testing.T) {
+		theFile := writeTestSortedFile(t, set)
+		band := slices.Collect(theFile.WithPrefix("ban"))
+		expect(t, slices.Equal(band, []string{"ban", "banana", "band", "bandana"}), "Expected the ban members, got %v", band)
+		expect(t, theFile.CountPrefix("ap") == 2, "Expected 2 members starting ap, got %d", theFile.CountPrefix("ap"))
+		expect(t, theFile.CountPrefix("zebra") == 0, "Expected no members starting zebra")
+		expect(t, theFile.CountPrefix("") == set.Count(), "Expected every member to start with the empty prefix")
+	})
+
+	t.Run("SortedFile members outlive Close", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "set")
+		WriteSortedFile(path, New("a", "b"))
+		theFile, _ := OpenSortedFile(path)
+		all := slices.Collect(theFile.All())
+		expect(t, theFile.Close() == nil, "Unexpected error closing")
+		expect(t, slices.Equal(all, []string{"a", "b"}), "Expected [a b], got %v", all)
+	})
+
+	t.Run("SortedFile holds an empty Set", func(t *testing.T) {
+		theFile := writeTestSortedFile(t, New[string]())
+		expect(t, theFile.Count() == 0 && !theFile.Contains(""), "Expected an empty SortedFile")
+	})
+
+	t.Run("OpenSortedFile rejects damaged and unknown files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "set")
+		WriteSortedFile(path, New("a", "b", "c"))
+		valid, _ := os.ReadFile(path)
+
+		damage := map[string]func([]byte) []byte{
+			"empty":   func(data []byte) []byte { return nil },
+			"magic":   func(data []byte) []byte { data[0] = 'X'; return data },
+			"version": func(data []byte) []byte { binary.LittleEndian.PutUint32(data[8:], 2); return data },
+			"count":   func(data []byte) []byte { binary.LittleEndian.PutUint64(data[16:], 1<<40); return data },
+			"offsets": func(data []byte) []byte {
+				binary.LittleEndian.PutUint64(data[sortedFileHeaderSize+8:], 99)
+				return data
+			},
+			"truncated": func(data []byte) []byte { return data[:len(data)-1] },
+		}
+		for name, damage := range damage {
+			os.WriteFile(path, damage(slices.Clone(valid)), 0o644)
+			_, err := OpenSortedFile(path)
+			expect(t, errors.Is(err, ErrCorruptFile), "Expected ErrCorruptFile for a damaged %s, got %v", name, err)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+//go:build unix
+
+package goset
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// mapFile maps the file at path into memory read-only, returning its contents and a function to unmap them.
+func mapFile(path string) ([]byte, func() error, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	if info.Size() == 0 {
+		return nil, func() error { return nil }, nil
+	}
+	if int64(int(info.Size())) != info.Size() {
+		return nil, nil, errors.New("goset: file too large to map")
+	}
+	data, err := syscall.Mmap(int(file.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, &os.PathError{Op: "mmap", Path: path, Err: err}
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
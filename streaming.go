@@ -0,0 +1,103 @@
+package goset
+
+import "iter"
+
+// Sorted returns an iterator over the members of theSet in the same order as AsSortedList, suitable as
+// an input to StreamUnion, StreamIntersect and StreamMinus
+func (theSet Set[T]) Sorted() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, member := range theSet.AsSortedList() {
+			if !yield(member) {
+				return
+			}
+		}
+	}
+}
+
+// StreamUnion returns an iterator over the members of a or b, merging two sequences already sorted by
+// compare into one sorted sequence without duplicates. A nil compare uses the default ordering of
+// AsSortedList. Only one member of each input is held at a time; the result is undefined if either
+// input is not sorted.
+func StreamUnion[T comparable](compare CompareFunc[T], a, b iter.Seq[T]) iter.Seq[T] {
+	return merge(compare, a, b, true, true, true)
+}
+
+// StreamIntersect returns an iterator over the members of both a and b, in the manner of StreamUnion
+func StreamIntersect[T comparable](compare CompareFunc[T], a, b iter.Seq[T]) iter.Seq[T] {
+	return merge(compare, a, b, false, false, true)
+}
+
+// StreamMinus returns an iterator over the members of a that are not in b, in the manner of StreamUnion
+func StreamMinus[T comparable](compare CompareFunc[T], a, b iter.Seq[T]) iter.Seq[T] {
+	return merge(compare, a, b, true, false, false)
+}
+
+// StreamSymmetricDifference returns an iterator over the members of either a or b but not both, in the
+// manner of StreamUnion
+func StreamSymmetricDifference[T comparable](compare CompareFunc[T], a, b iter.Seq[T]) iter.Seq[T] {
+	return merge(compare, a, b, true, true, false)
+}
+
+// merge walks the sorted sequences a and b in step, yielding members found only in a if onlyA, only in
+// b if onlyB, and in both if both. It stops early once the remaining members could not be yielded.
+func merge[T comparable](compare CompareFunc[T], a, b iter.Seq[T], onlyA, onlyB, both bool) iter.Seq[T] {
+	if compare == nil {
+		compare = memberCompareFunc[T]()
+	}
+	return func(yield func(T) bool) {
+		nextA, stopA := iter.Pull(distinct(compare, a))
+		defer stopA()
+		nextB, stopB := iter.Pull(distinct(compare, b))
+		defer stopB()
+
+		x, okA := nextA()
+		y, okB := nextB()
+		for okA && okB {
+			switch order := compare(x, y); {
+			case order < 0:
+				if onlyA && !yield(x) {
+					return
+				}
+				x, okA = nextA()
+			case order > 0:
+				if onlyB && !yield(y) {
+					return
+				}
+				y, okB = nextB()
+			default:
+				if both && !yield(x) {
+					return
+				}
+				x, okA = nextA()
+				y, okB = nextB()
+			}
+		}
+		for ; okA && onlyA; x, okA = nextA() {
+			if !yield(x) {
+				return
+			}
+		}
+		for ; okB && onlyB; y, okB = nextB() {
+			if !yield(y) {
+				return
+			}
+		}
+	}
+}
+
+// distinct returns an iterator over the sorted sequence seq, skipping members equal to the one before.
+func distinct[T comparable](compare CompareFunc[T], seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var previous T
+		first := true
+		for member := range seq {
+			if !first && compare(previous, member) == 0 {
+				continue
+			}
+			if !yield(member) {
+				return
+			}
+			previous, first = member, false
+		}
+	}
+}
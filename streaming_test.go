@@ -0,0 +1,91 @@
+package goset
+
+import (
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSet_Sorted(t *testing.T) {
+	t.Run("Sorted yields members in AsSortedList order", func(t *testing.T) {
+		set := NewWithComparator(func(a, b int) bool { return a > b }, 1, 3, 2)
+		sorted := slices.Collect(set.Sorted())
+		expect(t, slices.Equal(sorted, []int{3, 2, 1}), "Expected [3 2 1], got %v", sorted)
+	})
+}
+
+func TestStreamOperations(t *testing.T) {
+	operations := map[string]struct {
+		stream func(CompareFunc[int], iter.Seq[int], iter.Seq[int]) iter.Seq[int]
+		set    func(Set[int], Set[int]) Set[int]
+	}{
+		"StreamUnion":               {StreamUnion[int], Set[int].Union},
+		"StreamIntersect":           {StreamIntersect[int], Set[int].Intersect},
+		"StreamMinus":               {StreamMinus[int], Set[int].Minus},
+		"StreamSymmetricDifference": {StreamSymmetricDifference[int], func(a, b Set[int]) Set[int] { return a.Minus(b).Union(b.Minus(a)) }},
+	}
+	random := rand.New(rand.NewSource(1))
+	randomSet := func() Set[int] {
+		set := New[int]()
+		for i := random.Intn(50); i > 0; i-- {
+			set.Add(random.Intn(60))
+		}
+		return set
+	}
+
+	for name, operation := range operations {
+		t.Run(name+" matches the Set operation in sorted order", func(t *testing.T) {
+			for trial := 0; trial < 100; trial++ {
+				a, b := randomSet(), randomSet()
+				expected := operation.set(a, b).AsSortedList()
+				actual := slices.Collect(operation.stream(nil, a.Sorted(), b.Sorted()))
+				expect(t, slices.Equal(actual, expected), "Expected %v for %s and %s, got %v", expected, a, b, actual)
+			}
+		})
+
+		t.Run(name+" follows a custom ordering", func(t *testing.T) {
+			descending := CompareFunc[int](func(a, b int) int { return b - a })
+			a, b := NewWithCompareFunc(descending, 1, 2, 3, 5), NewWithCompareFunc(descending, 2, 4, 5)
+			expected := operation.set(a, b).Clone()
+			expected.comparator = descending.Comparator()
+			actual := slices.Collect(operation.stream(descending, a.Sorted(), b.Sorted()))
+			expect(t, slices.Equal(actual, expected.AsSortedList()), "Expected %v, got %v", expected.AsSortedList(), actual)
+		})
+
+		t.Run(name+" stops early when the consumer does", func(t *testing.T) {
+			for range operation.stream(nil, slices.Values([]int{1, 2, 3}), slices.Values([]int{2, 3, 4})) {
+				break
+			}
+		})
+	}
+
+	t.Run("stream operations skip duplicates in their inputs", func(t *testing.T) {
+		a, b := slices.Values([]int{1, 1, 2, 2, 3}), slices.Values([]int{2, 2, 2, 4})
+		union := slices.Collect(StreamUnion(nil, a, b))
+		expect(t, slices.Equal(union, []int{1, 2, 3, 4}), "Expected [1 2 3 4], got %v", union)
+		symmetric := slices.Collect(StreamSymmetricDifference(nil, a, b))
+		expect(t, slices.Equal(symmetric, []int{1, 3, 4}), "Expected [1 3 4], got %v", symmetric)
+	})
+
+	t.Run("stream operations compare ordered members without allocating for each", func(t *testing.T) {
+		a, b := make([]int, 10000), make([]int, 10000)
+		for idx := range a {
+			a[idx], b[idx] = 2*idx, 2*idx+1
+		}
+		allocs := testing.AllocsPerRun(5, func() {
+			for range StreamUnion(nil, slices.Values(a), slices.Values(b)) {
+			}
+		})
+		expect(t, allocs < 100, "Expected a fixed number of allocations, got %v", allocs)
+	})
+
+	t.Run("stream operations use the default ordering for other comparable types", func(t *testing.T) {
+		type point struct{ x, y int }
+		a, b := New(point{1, 2}, point{0, 5}, point{1, 1}), New(point{1, 1}, point{2, 0})
+		intersection := slices.Collect(StreamIntersect(nil, a.Sorted(), b.Sorted()))
+		expect(t, slices.Equal(intersection, []point{{1, 1}}), "Expected [{1 1}], got %v", intersection)
+		union := slices.Collect(StreamUnion(nil, a.Sorted(), b.Sorted()))
+		expect(t, slices.Equal(union, a.Union(b).AsSortedList()), "Expected %v, got %v", a.Union(b).AsSortedList(), union)
+	})
+}
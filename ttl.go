@@ -0,0 +1,143 @@
+package goset
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// TTLSet is a set whose members expire a given time after they are added. Expired members are
+// ignored by Contains, Count, AsList and Snapshot, and are removed by Sweep.
+// It is safe for concurrent use.
+type TTLSet[T comparable] struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	expires map[T]time.Time
+}
+
+// NewTTL returns a new, empty TTLSet telling the time with now, or time.Now if now is nil
+func NewTTL[T comparable](now func() time.Time) *TTLSet[T] {
+	if now == nil {
+		now = time.Now
+	}
+	return &TTLSet[T]{
+		now:     now,
+		expires: map[T]time.Time{},
+	}
+}
+
+// Add adds members to theSet, expiring ttl from now. Adding a member that is already present
+// replaces its expiry. Members added with a non-positive ttl never expire.
+func (theSet *TTLSet[T]) Add(ttl time.Duration, members ...T) *TTLSet[T] {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	var expiry time.Time
+	if ttl > 0 {
+		expiry = theSet.now().Add(ttl)
+	}
+	for _, member := range members {
+		theSet.expires[member] = expiry
+	}
+	return theSet
+}
+
+// Remove removes members from theSet, ignoring any that are not present
+func (theSet *TTLSet[T]) Remove(members ...T) *TTLSet[T] {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	for _, member := range members {
+		delete(theSet.expires, member)
+	}
+	return theSet
+}
+
+// Contains returns a boolean indicating whether theSet contains all the given values, and none have expired
+func (theSet *TTLSet[T]) Contains(values ...T) bool {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	now := theSet.now()
+	for _, value := range values {
+		expiry, ok := theSet.expires[value]
+		if !ok {
+			return false
+		}
+		if isExpired(expiry, now) {
+			delete(theSet.expires, value)
+			return false
+		}
+	}
+	return true
+}
+
+// ExpiresAt returns the time at which member expires, and whether it is present. A zero time means
+// member never expires.
+func (theSet *TTLSet[T]) ExpiresAt(member T) (time.Time, bool) {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	expiry, ok := theSet.expires[member]
+	if !ok || isExpired(expiry, theSet.now()) {
+		return time.Time{}, false
+	}
+	return expiry, true
+}
+
+// Count returns the number of members of theSet that have not expired
+func (theSet *TTLSet[T]) Count() int {
+	return theSet.Snapshot().Count()
+}
+
+// AsList returns a slice of the members of theSet that have not expired
+func (theSet *TTLSet[T]) AsList() []T {
+	return theSet.Snapshot().AsList()
+}
+
+// Snapshot returns a Set of the members of theSet that have not expired
+func (theSet *TTLSet[T]) Snapshot() Set[T] {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	now := theSet.now()
+	live := New[T]()
+	for member, expiry := range theSet.expires {
+		if !isExpired(expiry, now) {
+			live.Add(member)
+		}
+	}
+	return live
+}
+
+// Sweep removes expired members from theSet, and returns how many were removed
+func (theSet *TTLSet[T]) Sweep() int {
+	theSet.mu.Lock()
+	defer theSet.mu.Unlock()
+	now := theSet.now()
+	swept := 0
+	for member, expiry := range theSet.expires {
+		if isExpired(expiry, now) {
+			delete(theSet.expires, member)
+			swept++
+		}
+	}
+	return swept
+}
+
+// StartSweeper calls Sweep every interval in a background goroutine, until ctx is done. Without a
+// sweeper, expired members are only removed when Contains encounters them.
+func (theSet *TTLSet[T]) StartSweeper(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				theSet.Sweep()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// isExpired returns a boolean indicating whether a member with the given expiry has expired at now.
+func isExpired(expiry, now time.Time) bool {
+	return !expiry.IsZero() && !now.Before(expiry)
+}
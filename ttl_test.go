@@ -0,0 +1,111 @@
+package goset
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock for tests that only moves when told to.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (clock *fakeClock) Now() time.Time {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+	return clock.now
+}
+
+func (clock *fakeClock) Advance(d time.Duration) {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+	clock.now = clock.now.Add(d)
+}
+
+func TestTTLSet(t *testing.T) {
+	t.Run("Members are present until their TTL passes", func(t *testing.T) {
+		clock := &fakeClock{now: time.Unix(0, 0)}
+		set := NewTTL[string](clock.Now)
+		set.Add(time.Minute, "ryu")
+		set.Add(time.Hour, "ken")
+		expect(t, set.Contains("ryu", "ken"), "Expected both members to be present")
+
+		clock.Advance(time.Minute)
+		expect(t, !set.Contains("ryu"), "Expected ryu to have expired")
+		expect(t, set.Contains("ken"), "Expected ken to be present")
+		expect(t, set.Count() == 1, "Expected Count 1, got %v", set.Count())
+	})
+
+	t.Run("Re-adding a member replaces its expiry", func(t *testing.T) {
+		clock := &fakeClock{now: time.Unix(0, 0)}
+		set := NewTTL[string](clock.Now)
+		set.Add(time.Minute, "ryu")
+		clock.Advance(50 * time.Second)
+		set.Add(time.Minute, "ryu")
+		clock.Advance(50 * time.Second)
+		expect(t, set.Contains("ryu"), "Expected ryu's expiry to have been extended")
+		expiry, ok := set.ExpiresAt("ryu")
+		expect(t, ok && expiry.Equal(time.Unix(110, 0)), "Expected expiry at 110s, got %v", expiry)
+	})
+
+	t.Run("Members added with a non-positive TTL never expire", func(t *testing.T) {
+		clock := &fakeClock{now: time.Unix(0, 0)}
+		set := NewTTL[int](clock.Now)
+		set.Add(0, 1)
+		clock.Advance(24 * 365 * time.Hour)
+		expect(t, set.Contains(1), "Expected member without TTL to remain")
+	})
+
+	t.Run("Snapshot includes only live members", func(t *testing.T) {
+		clock := &fakeClock{now: time.Unix(0, 0)}
+		set := NewTTL[int](clock.Now)
+		set.Add(time.Second, 1, 2)
+		set.Add(time.Minute, 3)
+		clock.Advance(time.Second)
+		snapshot := set.Snapshot()
+		expect(t, snapshot.Equals(New(3)), "Expected {3}, got %s", snapshot)
+		expect(t, len(set.AsList()) == 1, "Expected AsList to return only live members")
+	})
+
+	t.Run("Sweep removes expired members", func(t *testing.T) {
+		clock := &fakeClock{now: time.Unix(0, 0)}
+		set := NewTTL[int](clock.Now)
+		set.Add(time.Second, 1, 2)
+		set.Add(time.Minute, 3)
+		expect(t, set.Sweep() == 0, "Expected nothing to Sweep yet")
+		clock.Advance(time.Second)
+		expect(t, set.Sweep() == 2, "Expected 2 members to be swept")
+		expect(t, len(set.expires) == 1, "Expected expired members to be removed from storage")
+	})
+
+	t.Run("Remove removes members regardless of TTL", func(t *testing.T) {
+		set := NewTTL[int](nil)
+		set.Add(time.Hour, 1, 2)
+		set.Remove(1)
+		expect(t, !set.Contains(1) && set.Contains(2), "Expected only 1 to be removed")
+	})
+
+	t.Run("StartSweeper sweeps in the background until cancelled", func(t *testing.T) {
+		clock := &fakeClock{now: time.Unix(0, 0)}
+		set := NewTTL[int](clock.Now)
+		set.Add(time.Second, 1)
+		clock.Advance(time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		set.StartSweeper(ctx, time.Millisecond)
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			set.mu.Lock()
+			remaining := len(set.expires)
+			set.mu.Unlock()
+			if remaining == 0 {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+		t.Errorf("Expected the sweeper to remove the expired member")
+	})
+}
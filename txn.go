@@ -0,0 +1,183 @@
+package goset
+
+import "errors"
+
+// ErrTxnClosed is returned when committing or rolling back a Txn that has already been committed or rolled back
+var ErrTxnClosed = errors.New("goset: transaction already committed or rolled back")
+
+// ErrInvalidSavepoint is returned when rolling back to a Savepoint that was discarded by an earlier rollback
+var ErrInvalidSavepoint = errors.New("goset: invalid savepoint")
+
+// Txn buffers Add and Remove operations against a Set, applying them all at once on Commit or
+// discarding them on Rollback. Contains, Count and AsList reflect the buffered operations.
+// A Txn is not safe for concurrent use, and the underlying Set should not be modified while it is open.
+type Txn[T comparable] struct {
+	set        Set[T]
+	pending    map[T]bool
+	undo       []txnUndo[T]
+	savepoints []Savepoint
+	nextID     int
+	closed     bool
+}
+
+// Savepoint marks a point within a Txn that can be rolled back to with RollbackTo
+type Savepoint struct {
+	txn      any
+	id       int
+	position int
+}
+
+// txnUndo records the state of a member in pending before an operation changed it.
+type txnUndo[T comparable] struct {
+	member  T
+	present bool
+	pending bool
+}
+
+// Begin returns a new Txn buffering operations against theSet
+func (theSet Set[T]) Begin() *Txn[T] {
+	return &Txn[T]{
+		set:     theSet,
+		pending: map[T]bool{},
+	}
+}
+
+// Add buffers the addition of members to the Set
+func (txn *Txn[T]) Add(members ...T) *Txn[T] {
+	txn.record(true, members)
+	return txn
+}
+
+// Remove buffers the removal of members from the Set
+func (txn *Txn[T]) Remove(members ...T) *Txn[T] {
+	txn.record(false, members)
+	return txn
+}
+
+// record sets the pending state of members, logging their previous state for RollbackTo.
+func (txn *Txn[T]) record(present bool, members []T) {
+	if txn.closed {
+		panic(ErrTxnClosed)
+	}
+	for _, member := range members {
+		previous, pending := txn.pending[member]
+		txn.undo = append(txn.undo, txnUndo[T]{member: member, present: previous, pending: pending})
+		txn.pending[member] = present
+	}
+}
+
+// Contains returns a boolean indicating whether the Set would contain all the given values if txn were committed
+func (txn *Txn[T]) Contains(values ...T) bool {
+	for _, value := range values {
+		if present, pending := txn.pending[value]; pending {
+			if !present {
+				return false
+			}
+		} else if !txn.set.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Count returns the set cardinality the Set would have if txn were committed
+func (txn *Txn[T]) Count() int {
+	count := txn.set.Count()
+	for member, present := range txn.pending {
+		switch inSet := txn.set.Contains(member); {
+		case present && !inSet:
+			count++
+		case !present && inSet:
+			count--
+		}
+	}
+	return count
+}
+
+// AsList returns a slice of the values the Set would contain if txn were committed
+func (txn *Txn[T]) AsList() []T {
+	list := make([]T, 0, txn.Count())
+	for member := range txn.set.members {
+		if present, pending := txn.pending[member]; !pending || present {
+			list = append(list, member)
+		}
+	}
+	for member, present := range txn.pending {
+		if present && !txn.set.Contains(member) {
+			list = append(list, member)
+		}
+	}
+	return list
+}
+
+// Savepoint returns a Savepoint marking the current state of txn, which only txn will accept. Like Add
+// and Remove, it panics with ErrTxnClosed if txn has been committed or rolled back.
+func (txn *Txn[T]) Savepoint() Savepoint {
+	if txn.closed {
+		panic(ErrTxnClosed)
+	}
+	savepoint := Savepoint{txn: txn, id: txn.nextID, position: len(txn.undo)}
+	txn.nextID++
+	txn.savepoints = append(txn.savepoints, savepoint)
+	return savepoint
+}
+
+// RollbackTo discards the operations buffered since savepoint was taken, along with any later Savepoints.
+// savepoint itself remains valid, and may be rolled back to again.
+func (txn *Txn[T]) RollbackTo(savepoint Savepoint) error {
+	if txn.closed {
+		return ErrTxnClosed
+	}
+	live := -1
+	for idx, candidate := range txn.savepoints {
+		if candidate == savepoint {
+			live = idx
+		}
+	}
+	if live < 0 {
+		return ErrInvalidSavepoint
+	}
+	txn.savepoints = txn.savepoints[:live+1]
+	for idx := len(txn.undo) - 1; idx >= savepoint.position; idx-- {
+		entry := txn.undo[idx]
+		if entry.pending {
+			txn.pending[entry.member] = entry.present
+		} else {
+			delete(txn.pending, entry.member)
+		}
+	}
+	txn.undo = txn.undo[:savepoint.position]
+	return nil
+}
+
+// Commit applies the buffered operations to the Set and closes txn
+func (txn *Txn[T]) Commit() error {
+	if txn.closed {
+		return ErrTxnClosed
+	}
+	for member, present := range txn.pending {
+		if present {
+			txn.set.Add(member)
+		} else {
+			txn.set.Remove(member)
+		}
+	}
+	txn.close()
+	return nil
+}
+
+// Rollback discards the buffered operations and closes txn
+func (txn *Txn[T]) Rollback() error {
+	if txn.closed {
+		return ErrTxnClosed
+	}
+	txn.close()
+	return nil
+}
+
+func (txn *Txn[T]) close() {
+	txn.closed = true
+	txn.pending = nil
+	txn.undo = nil
+	txn.savepoints = nil
+}
@@ -0,0 +1,106 @@
+package goset
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTxn(t *testing.T) {
+	t.Run("Operations are not visible in the Set until Commit", func(t *testing.T) {
+		set := New("ryu", "ken")
+		txn := set.Begin()
+		txn.Add("guile").Remove("ken")
+		expect(t, set.Equals(New("ryu", "ken")), "Expected Set to be unchanged before Commit, got %s", set)
+		expect(t, txn.Commit() == nil, "Expected Commit to succeed")
+		expect(t, set.Equals(New("ryu", "guile")), "Expected Set to be changed after Commit, got %s", set)
+	})
+
+	t.Run("Rollback discards all operations", func(t *testing.T) {
+		set := New("ryu", "ken")
+		txn := set.Begin()
+		txn.Add("guile").Remove("ryu", "ken")
+		expect(t, txn.Rollback() == nil, "Expected Rollback to succeed")
+		expect(t, set.Equals(New("ryu", "ken")), "Expected Set to be unchanged after Rollback, got %s", set)
+	})
+
+	t.Run("Txn shows a read-your-writes view", func(t *testing.T) {
+		set := New("ryu", "ken")
+		txn := set.Begin()
+		txn.Add("guile", "ryu").Remove("ken", "vega")
+		expect(t, txn.Contains("ryu", "guile"), "Expected Txn to contain ryu and guile")
+		expect(t, !txn.Contains("ken"), "Expected Txn not to contain removed ken")
+		expect(t, txn.Count() == 2, "Expected Count 2, got %v", txn.Count())
+		actual := New(txn.AsList()...)
+		expect(t, actual.Equals(New("ryu", "guile")), "Expected AsList {guile, ryu}, got %s", actual)
+		txn.Add("ken")
+		expect(t, txn.Contains("ken") && txn.Count() == 3, "Expected later operations to override earlier ones")
+	})
+
+	t.Run("RollbackTo discards operations since the Savepoint", func(t *testing.T) {
+		set := New("ryu")
+		txn := set.Begin()
+		txn.Add("ken")
+		outer := txn.Savepoint()
+		txn.Add("guile").Remove("ryu")
+		inner := txn.Savepoint()
+		txn.Add("vega")
+		expect(t, txn.RollbackTo(inner) == nil, "Expected RollbackTo(inner) to succeed")
+		expect(t, txn.Contains("guile") && !txn.Contains("vega") && !txn.Contains("ryu"), "Expected only vega to be rolled back")
+		expect(t, txn.RollbackTo(outer) == nil, "Expected RollbackTo(outer) to succeed")
+		expect(t, txn.Contains("ryu", "ken") && !txn.Contains("guile"), "Expected guile and Remove(ryu) to be rolled back")
+		expect(t, txn.Commit() == nil, "Expected Commit to succeed")
+		expect(t, set.Equals(New("ryu", "ken")), "Expected {ken, ryu}, got %s", set)
+	})
+
+	t.Run("Savepoints after a rolled back Savepoint are invalid", func(t *testing.T) {
+		txn := New[int]().Begin()
+		outer := txn.Savepoint()
+		txn.Add(1)
+		inner := txn.Savepoint()
+		expect(t, txn.RollbackTo(outer) == nil, "Expected RollbackTo(outer) to succeed")
+		txn.Add(2, 3)
+		err := txn.RollbackTo(inner)
+		expect(t, errors.Is(err, ErrInvalidSavepoint), "Expected ErrInvalidSavepoint, got %v", err)
+		expect(t, txn.RollbackTo(outer) == nil, "Expected outer to remain valid")
+		expect(t, txn.Count() == 0, "Expected all operations to be rolled back, got %v", txn.Count())
+	})
+
+	t.Run("A Savepoint from another Txn is invalid", func(t *testing.T) {
+		set := New[int]()
+		first, second := set.Begin(), set.Begin()
+		first.Add(1)
+		foreign := first.Savepoint()
+		second.Add(1)
+		second.Savepoint()
+		err := second.RollbackTo(foreign)
+		expect(t, errors.Is(err, ErrInvalidSavepoint), "Expected ErrInvalidSavepoint, got %v", err)
+		expect(t, second.Contains(1), "Expected second to be unchanged")
+	})
+
+	t.Run("A closed Txn cannot be committed or rolled back", func(t *testing.T) {
+		txn := New[int]().Begin()
+		savepoint := txn.Savepoint()
+		expect(t, txn.Commit() == nil, "Expected first Commit to succeed")
+		expect(t, errors.Is(txn.Commit(), ErrTxnClosed), "Expected ErrTxnClosed from second Commit")
+		expect(t, errors.Is(txn.Rollback(), ErrTxnClosed), "Expected ErrTxnClosed from Rollback")
+		expect(t, errors.Is(txn.RollbackTo(savepoint), ErrTxnClosed), "Expected ErrTxnClosed from RollbackTo")
+	})
+
+	t.Run("Adding to a closed Txn panics", func(t *testing.T) {
+		txn := New[int]().Begin()
+		_ = txn.Rollback()
+		defer func() {
+			expect(t, recover() == ErrTxnClosed, "Expected Add to panic with ErrTxnClosed")
+		}()
+		txn.Add(1)
+	})
+
+	t.Run("Taking a Savepoint of a closed Txn panics", func(t *testing.T) {
+		txn := New[int]().Begin()
+		_ = txn.Commit()
+		defer func() {
+			expect(t, recover() == ErrTxnClosed, "Expected Savepoint to panic with ErrTxnClosed")
+		}()
+		txn.Savepoint()
+	})
+}
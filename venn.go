@@ -0,0 +1,96 @@
+package goset
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// MaxRegionSets is the maximum number of Sets that Regions can divide into regions
+const MaxRegionSets = 64
+
+// Regions returns the members of each non-empty region of a Venn diagram of sets. Each region is keyed
+// by a bitmask with bit i set when its members are in sets[i], and cleared when they are not, so
+// that e.g. with three sets, key 0b101 holds the members of sets[0] and sets[2] but not sets[1].
+// Each member is visited once per Set containing it. Regions panics if given more than MaxRegionSets sets.
+func Regions[T comparable](sets ...Set[T]) map[uint64]Set[T] {
+	if len(sets) > MaxRegionSets {
+		panic(fmt.Sprintf("goset: Regions supports at most %d sets, got %d", MaxRegionSets, len(sets)))
+	}
+	masks := map[T]uint64{}
+	for idx, set := range sets {
+		for member := range set.members {
+			masks[member] |= 1 << idx
+		}
+	}
+
+	regions := map[uint64]Set[T]{}
+	for member, mask := range masks {
+		region, ok := regions[mask]
+		if !ok {
+			region = New[T]()
+			regions[mask] = region
+		}
+		region.Add(member)
+	}
+	return regions
+}
+
+// vennLayout positions the circles, their labels, and the label of each region of a Venn diagram.
+type vennLayout struct {
+	width, height int
+	circles       [][2]int
+	names         [][2]int
+	regions       map[uint64][2]int
+}
+
+const vennRadius = 100
+
+var vennLayouts = map[int]vennLayout{
+	2: {
+		width: 400, height: 280,
+		circles: [][2]int{{150, 150}, {250, 150}},
+		names:   [][2]int{{100, 30}, {300, 30}},
+		regions: map[uint64][2]int{0b01: {100, 155}, 0b10: {300, 155}, 0b11: {200, 155}},
+	},
+	3: {
+		width: 400, height: 380,
+		circles: [][2]int{{150, 140}, {250, 140}, {200, 227}},
+		names:   [][2]int{{90, 25}, {310, 25}, {200, 350}},
+		regions: map[uint64][2]int{
+			0b001: {110, 115}, 0b010: {290, 115}, 0b100: {200, 285},
+			0b011: {200, 100}, 0b101: {145, 210}, 0b110: {255, 210},
+			0b111: {200, 175},
+		},
+	},
+}
+
+// VennSVG renders a Venn diagram of two or three sets as an SVG document, labelling each circle with
+// the corresponding entry of names and each region with its size. regions is as returned by Regions.
+func VennSVG[T comparable](names []string, regions map[uint64]Set[T]) (string, error) {
+	layout, ok := vennLayouts[len(names)]
+	if !ok {
+		return "", errors.New("goset: VennSVG supports only 2 or 3 sets")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		layout.width, layout.height, layout.width, layout.height))
+	colors := []string{"#e41a1c", "#377eb8", "#4daf4a"}
+	for idx, center := range layout.circles {
+		sb.WriteString(fmt.Sprintf(`  <circle cx="%d" cy="%d" r="%d" fill="%s" fill-opacity="0.25" stroke="%s"/>`+"\n",
+			center[0], center[1], vennRadius, colors[idx], colors[idx]))
+	}
+	for idx, position := range layout.names {
+		sb.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" text-anchor="middle" font-family="sans-serif" font-weight="bold">%s</text>`+"\n",
+			position[0], position[1], html.EscapeString(names[idx])))
+	}
+	for mask := uint64(1); mask < 1<<len(names); mask++ {
+		position := layout.regions[mask]
+		sb.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" text-anchor="middle" font-family="sans-serif">%d</text>`+"\n",
+			position[0], position[1], regions[mask].Count()))
+	}
+	sb.WriteString("</svg>\n")
+	return sb.String(), nil
+}
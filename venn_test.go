@@ -0,0 +1,81 @@
+package goset
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestRegions(t *testing.T) {
+	t.Run("Regions divides members by which sets contain them", func(t *testing.T) {
+		a := New(1, 2, 3, 4)
+		b := New(3, 4, 5)
+		c := New(4, 5, 6)
+		regions := Regions(a, b, c)
+		expected := map[uint64]Set[int]{
+			0b001: New(1, 2),
+			0b011: New(3),
+			0b111: New(4),
+			0b110: New(5),
+			0b100: New(6),
+		}
+		expect(t, len(regions) == len(expected), "Expected %v regions, got %v", len(expected), regions)
+		for mask, members := range expected {
+			expect(t, regions[mask].Equals(members), "Expected region %03b = %s, got %s", mask, members, regions[mask])
+		}
+	})
+
+	t.Run("Regions of no sets is empty", func(t *testing.T) {
+		expect(t, len(Regions[int]()) == 0, "Expected no regions")
+	})
+
+	t.Run("Regions of eight sets partitions their union", func(t *testing.T) {
+		sets := []Set[int]{}
+		for bit := 0; bit < 8; bit++ {
+			set := New[int]()
+			for member := 0; member < 256; member++ {
+				if member&(1<<bit) != 0 {
+					set.Add(member)
+				}
+			}
+			sets = append(sets, set)
+		}
+		regions := Regions(sets...)
+		expect(t, len(regions) == 255, "Expected 255 non-empty regions, got %v", len(regions))
+		for mask, members := range regions {
+			expect(t, members.Equals(New(int(mask))), "Expected region %08b = {%v}, got %s", mask, mask, members)
+		}
+	})
+
+	t.Run("Regions panics for more than MaxRegionSets sets", func(t *testing.T) {
+		defer func() {
+			expect(t, recover() != nil, "Expected Regions to panic")
+		}()
+		Regions(make([]Set[int], MaxRegionSets+1)...)
+	})
+}
+
+func TestVennSVG(t *testing.T) {
+	t.Run("VennSVG renders valid SVG with names and region sizes", func(t *testing.T) {
+		regions := Regions(New(1, 2, 3), New(3, 4))
+		svg, err := VennSVG([]string{"a & b", "c"}, regions)
+		expect(t, err == nil, "Unexpected error %v", err)
+		expect(t, xml.Unmarshal([]byte(svg), new(interface{})) == nil, "Expected well-formed XML:\n%s", svg)
+		expect(t, strings.Count(svg, "<circle") == 2, "Expected 2 circles:\n%s", svg)
+		expect(t, strings.Contains(svg, ">a &amp; b</text>"), "Expected escaped name:\n%s", svg)
+		expect(t, strings.Contains(svg, `x="100" y="155" text-anchor="middle" font-family="sans-serif">2</text>`), "Expected a-only region of 2:\n%s", svg)
+		expect(t, strings.Contains(svg, `x="200" y="155" text-anchor="middle" font-family="sans-serif">1</text>`), "Expected shared region of 1:\n%s", svg)
+	})
+
+	t.Run("VennSVG renders three sets", func(t *testing.T) {
+		svg, err := VennSVG([]string{"a", "b", "c"}, Regions(New(1), New(1), New(1)))
+		expect(t, err == nil, "Unexpected error %v", err)
+		expect(t, strings.Count(svg, "<circle") == 3, "Expected 3 circles:\n%s", svg)
+		expect(t, strings.Count(svg, ">0</text>") == 6, "Expected 6 empty regions:\n%s", svg)
+	})
+
+	t.Run("VennSVG rejects other numbers of sets", func(t *testing.T) {
+		_, err := VennSVG([]string{"a"}, Regions(New(1)))
+		expect(t, err != nil, "Expected an error for a single set")
+	})
+}
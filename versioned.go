@@ -0,0 +1,147 @@
+package goset
+
+import "fmt"
+
+// Version identifies a snapshot of a VersionedSet, as returned by VersionedSet.Snapshot
+type Version int
+
+// VersionedSet wraps a Set, recording each mutation so that it can be undone and redone,
+// and keeping immutable snapshots of past versions. It is not safe for concurrent use.
+type VersionedSet[T comparable] struct {
+	set       Set[T]
+	undo      []versionedChange[T]
+	redo      []versionedChange[T]
+	snapshots []Set[T]
+}
+
+// versionedChange records the members actually added and removed by a single mutation.
+type versionedChange[T comparable] struct {
+	added   []T
+	removed []T
+}
+
+// NewVersioned returns a new VersionedSet with the members of set as its initial state.
+// set itself is not modified.
+func NewVersioned[T comparable](set Set[T]) *VersionedSet[T] {
+	return &VersionedSet[T]{
+		set: set.cloneWithComparator(),
+	}
+}
+
+// Add adds members to theSet as a single undoable mutation, ignoring those already present
+func (theSet *VersionedSet[T]) Add(members ...T) *VersionedSet[T] {
+	change := versionedChange[T]{}
+	for _, member := range members {
+		if !theSet.set.Contains(member) {
+			theSet.set.Add(member)
+			change.added = append(change.added, member)
+		}
+	}
+	theSet.record(change)
+	return theSet
+}
+
+// Remove removes members from theSet as a single undoable mutation, ignoring those not present
+func (theSet *VersionedSet[T]) Remove(members ...T) *VersionedSet[T] {
+	change := versionedChange[T]{}
+	for _, member := range members {
+		if theSet.set.Contains(member) {
+			theSet.set.Remove(member)
+			change.removed = append(change.removed, member)
+		}
+	}
+	theSet.record(change)
+	return theSet
+}
+
+// record adds change to the undo history and discards the redo history, unless change is empty.
+func (theSet *VersionedSet[T]) record(change versionedChange[T]) {
+	if len(change.added) == 0 && len(change.removed) == 0 {
+		return
+	}
+	theSet.undo = append(theSet.undo, change)
+	theSet.redo = nil
+}
+
+// CanUndo returns a boolean indicating whether there is a mutation to Undo
+func (theSet *VersionedSet[T]) CanUndo() bool {
+	return len(theSet.undo) > 0
+}
+
+// CanRedo returns a boolean indicating whether there is an undone mutation to Redo
+func (theSet *VersionedSet[T]) CanRedo() bool {
+	return len(theSet.redo) > 0
+}
+
+// Undo reverts the most recent mutation, returning false if there was none
+func (theSet *VersionedSet[T]) Undo() bool {
+	if !theSet.CanUndo() {
+		return false
+	}
+	change := theSet.undo[len(theSet.undo)-1]
+	theSet.undo = theSet.undo[:len(theSet.undo)-1]
+	theSet.set.Remove(change.added...)
+	theSet.set.Add(change.removed...)
+	theSet.redo = append(theSet.redo, change)
+	return true
+}
+
+// Redo reapplies the most recently undone mutation, returning false if there was none
+func (theSet *VersionedSet[T]) Redo() bool {
+	if !theSet.CanRedo() {
+		return false
+	}
+	change := theSet.redo[len(theSet.redo)-1]
+	theSet.redo = theSet.redo[:len(theSet.redo)-1]
+	theSet.set.Remove(change.removed...)
+	theSet.set.Add(change.added...)
+	theSet.undo = append(theSet.undo, change)
+	return true
+}
+
+// Snapshot records the current members of theSet and returns a Version identifying them.
+// The snapshot is unaffected by later mutation, Undo or Redo.
+func (theSet *VersionedSet[T]) Snapshot() Version {
+	theSet.snapshots = append(theSet.snapshots, theSet.Current())
+	return Version(len(theSet.snapshots) - 1)
+}
+
+// At returns a copy of the members of theSet at version. It panics if version was not returned
+// by theSet.Snapshot.
+func (theSet *VersionedSet[T]) At(version Version) Set[T] {
+	if version < 0 || int(version) >= len(theSet.snapshots) {
+		panic(fmt.Sprintf("goset: unknown version %d", version))
+	}
+	snapshot := theSet.snapshots[version]
+	return snapshot.cloneWithComparator()
+}
+
+// DiffVersions returns the changes between two snapshots of theSet
+func (theSet *VersionedSet[T]) DiffVersions(from, to Version) SetDiff[T] {
+	return Diff(theSet.At(from), theSet.At(to))
+}
+
+// Current returns a copy of the current members of theSet
+func (theSet *VersionedSet[T]) Current() Set[T] {
+	return theSet.set.cloneWithComparator()
+}
+
+// Contains returns a boolean indicating whether theSet currently contains all the given values
+func (theSet *VersionedSet[T]) Contains(values ...T) bool {
+	return theSet.set.Contains(values...)
+}
+
+// Count returns the current set cardinality of theSet
+func (theSet *VersionedSet[T]) Count() int {
+	return theSet.set.Count()
+}
+
+// AsList returns a slice of the values currently in theSet
+func (theSet *VersionedSet[T]) AsList() []T {
+	return theSet.set.AsList()
+}
+
+// String returns a string representation of the current members of theSet
+func (theSet *VersionedSet[T]) String() string {
+	return theSet.set.String()
+}
@@ -0,0 +1,84 @@
+package goset
+
+import "testing"
+
+func TestVersionedSet(t *testing.T) {
+	t.Run("NewVersioned does not modify the original set", func(t *testing.T) {
+		original := New("ryu")
+		versioned := NewVersioned(original)
+		versioned.Add("ken")
+		expect(t, !original.Contains("ken"), "Expected original set to be unchanged")
+		expect(t, versioned.Contains("ryu", "ken"), "Expected VersionedSet to contain ryu and ken")
+	})
+
+	t.Run("VersionedSet keeps the Comparator of the original set", func(t *testing.T) {
+		original := NewWithComparator(byPersonAge, people...)
+		versioned := NewVersioned(original)
+		expect(t, versioned.Current().String() == original.String(), "Expected %s, got %s", original, versioned.Current())
+	})
+
+	t.Run("Undo reverts each mutation in turn, and Redo reapplies them", func(t *testing.T) {
+		versioned := NewVersioned(New("ryu"))
+		versioned.Add("ken", "guile")
+		versioned.Remove("ryu")
+		expect(t, versioned.Current().Equals(New("ken", "guile")), "Expected {guile, ken}, got %s", versioned)
+
+		expect(t, versioned.Undo(), "Expected Undo to succeed")
+		expect(t, versioned.Current().Equals(New("ryu", "ken", "guile")), "Expected {guile, ken, ryu}, got %s", versioned)
+		expect(t, versioned.Undo(), "Expected Undo to succeed")
+		expect(t, versioned.Current().Equals(New("ryu")), "Expected {ryu}, got %s", versioned)
+		expect(t, !versioned.Undo(), "Expected nothing left to Undo")
+
+		expect(t, versioned.Redo(), "Expected Redo to succeed")
+		expect(t, versioned.Current().Equals(New("ryu", "ken", "guile")), "Expected {guile, ken, ryu}, got %s", versioned)
+		expect(t, versioned.Redo(), "Expected Redo to succeed")
+		expect(t, versioned.Current().Equals(New("ken", "guile")), "Expected {guile, ken}, got %s", versioned)
+		expect(t, !versioned.Redo(), "Expected nothing left to Redo")
+	})
+
+	t.Run("Undo only reverts members that the mutation actually changed", func(t *testing.T) {
+		versioned := NewVersioned(New("ryu"))
+		versioned.Add("ryu", "ken")
+		versioned.Undo()
+		expect(t, versioned.Current().Equals(New("ryu")), "Expected ryu to survive Undo, got %s", versioned)
+	})
+
+	t.Run("Mutations without changes are not recorded", func(t *testing.T) {
+		versioned := NewVersioned(New("ryu"))
+		versioned.Add("ryu").Remove("ken")
+		expect(t, !versioned.CanUndo(), "Expected nothing to Undo")
+	})
+
+	t.Run("A new mutation discards the Redo history", func(t *testing.T) {
+		versioned := NewVersioned(New[int]())
+		versioned.Add(1)
+		versioned.Undo()
+		versioned.Add(2)
+		expect(t, !versioned.CanRedo(), "Expected nothing to Redo")
+		expect(t, versioned.Current().Equals(New(2)), "Expected {2}, got %s", versioned)
+	})
+
+	t.Run("Snapshots are immutable and can be diffed", func(t *testing.T) {
+		versioned := NewVersioned(New("ryu", "ken"))
+		first := versioned.Snapshot()
+		versioned.Add("guile").Remove("ken")
+		second := versioned.Snapshot()
+		versioned.Undo()
+		versioned.At(first).Add("vega")
+
+		expect(t, versioned.At(first).Equals(New("ryu", "ken")), "Expected first = {ken, ryu}, got %s", versioned.At(first))
+		expect(t, versioned.At(second).Equals(New("ryu", "guile")), "Expected second = {guile, ryu}, got %s", versioned.At(second))
+
+		diff := versioned.DiffVersions(first, second)
+		expect(t, diff.Added.Equals(New("guile")), "Expected Added = {guile}, got %s", diff.Added)
+		expect(t, diff.Removed.Equals(New("ken")), "Expected Removed = {ken}, got %s", diff.Removed)
+	})
+
+	t.Run("At panics for an unknown Version", func(t *testing.T) {
+		versioned := NewVersioned(New[int]())
+		defer func() {
+			expect(t, recover() != nil, "Expected At to panic for an unknown Version")
+		}()
+		versioned.At(Version(3))
+	})
+}